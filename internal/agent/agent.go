@@ -11,6 +11,7 @@ import (
 	"cmp"
 	"context"
 	_ "embed"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log/slog"
@@ -42,6 +43,9 @@ var titlePrompt []byte
 //go:embed templates/summary.md
 var summaryPrompt []byte
 
+//go:embed templates/worklog.md
+var worklogPrompt []byte
+
 type SessionAgentCall struct {
 	SessionID        string
 	Prompt           string
@@ -53,6 +57,12 @@ type SessionAgentCall struct {
 	TopK             *int64
 	FrequencyPenalty *float64
 	PresencePenalty  *float64
+	// MaxTurns limits the number of model calls made for this request. Zero
+	// means no limit.
+	MaxTurns int
+	// CostLimit pauses the agent once the session cost reaches it, in USD.
+	// Zero means no limit.
+	CostLimit float64
 }
 
 type SessionAgent interface {
@@ -66,6 +76,7 @@ type SessionAgent interface {
 	QueuedPrompts(sessionID string) int
 	ClearQueue(sessionID string)
 	Summarize(context.Context, string, fantasy.ProviderOptions) error
+	InspectPrompt(ctx context.Context, sessionID string) (PromptBreakdown, error)
 	Model() Model
 }
 
@@ -156,6 +167,10 @@ func (a *sessionAgent) Run(ctx context.Context, call SessionAgentCall) (*fantasy
 		return nil, fmt.Errorf("failed to get session: %w", err)
 	}
 
+	if call.CostLimit > 0 && currentSession.Cost >= call.CostLimit {
+		return nil, fmt.Errorf("%w: $%.2f spent, limit is $%.2f", ErrSessionCostLimit, currentSession.Cost, call.CostLimit)
+	}
+
 	msgs, err := a.getSessionMessages(ctx, currentSession)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get session messages: %w", err)
@@ -193,6 +208,8 @@ func (a *sessionAgent) Run(ctx context.Context, call SessionAgentCall) (*fantasy
 
 	var currentAssistant *message.Message
 	var shouldSummarize bool
+	var turnLimitReached bool
+	var costLimitReached bool
 	result, err := agent.Stream(genCtx, fantasy.AgentStreamCall{
 		Prompt:           call.Prompt,
 		Files:            files,
@@ -366,8 +383,9 @@ func (a *sessionAgent) Run(ctx context.Context, call SessionAgentCall) (*fantasy
 				finishReason = message.FinishReasonToolUse
 			}
 			currentAssistant.AddFinish(finishReason, "", "")
-			a.updateSessionUsage(a.largeModel, &currentSession, stepResult.Usage, a.openrouterCost(stepResult.ProviderMetadata))
 			sessionLock.Lock()
+			a.refreshSessionCost(genCtx, &currentSession)
+			a.updateSessionUsage(a.largeModel, &currentSession, stepResult.Usage, a.openrouterCost(stepResult.ProviderMetadata))
 			_, sessionErr := a.sessions.Save(genCtx, currentSession)
 			sessionLock.Unlock()
 			if sessionErr != nil {
@@ -392,6 +410,20 @@ func (a *sessionAgent) Run(ctx context.Context, call SessionAgentCall) (*fantasy
 				}
 				return false
 			},
+			func(_ []fantasy.StepResult) bool {
+				if call.CostLimit > 0 && currentSession.Cost >= call.CostLimit {
+					costLimitReached = true
+					return true
+				}
+				return false
+			},
+			func(steps []fantasy.StepResult) bool {
+				if call.MaxTurns > 0 && len(steps) >= call.MaxTurns {
+					turnLimitReached = true
+					return true
+				}
+				return false
+			},
 		},
 	})
 
@@ -440,15 +472,20 @@ func (a *sessionAgent) Run(ctx context.Context, call SessionAgentCall) (*fantasy
 				continue
 			}
 			content := "There was an error while executing the tool"
+			var metadata string
 			if isCancelErr {
 				content = "Tool execution canceled by user"
 			} else if isPermissionErr {
-				content = "User denied permission"
+				content = tools.ErrorContent(tools.ErrorKindPermissionDenied, "User denied permission")
+				if data, err := json.Marshal(tools.ErrorMetadata{ErrorKind: tools.ErrorKindPermissionDenied}); err == nil {
+					metadata = string(data)
+				}
 			}
 			toolResult := message.ToolResult{
 				ToolCallID: tc.ID,
 				Name:       tc.Name,
 				Content:    content,
+				Metadata:   metadata,
 				IsError:    true,
 			}
 			_, createErr = a.messages.Create(context.Background(), currentAssistant.SessionID, message.CreateMessageParams{
@@ -485,6 +522,22 @@ func (a *sessionAgent) Run(ctx context.Context, call SessionAgentCall) (*fantasy
 	}
 	wg.Wait()
 
+	// The agent still had tool calls to follow up on when it hit a limit.
+	if !shouldSummarize && currentAssistant.FinishReason() == message.FinishReasonToolUse {
+		switch {
+		case costLimitReached:
+			text := fmt.Sprintf("Paused because the session cost reached the $%.2f limit. Raise the limit to continue.", call.CostLimit)
+			if err := a.addLimitMessage(ctx, call, message.FinishReasonCostLimit, text, "Cost limit reached"); err != nil {
+				return nil, err
+			}
+		case turnLimitReached:
+			text := fmt.Sprintf("Stopped after %d turns due to the turn limit. The results above are partial; send a follow-up message to continue.", call.MaxTurns)
+			if err := a.addLimitMessage(ctx, call, message.FinishReasonTurnLimit, text, "Turn limit reached"); err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	if shouldSummarize {
 		a.activeRequests.Del(call.SessionID)
 		if summarizeErr := a.Summarize(genCtx, call.SessionID, call.ProviderOptions); summarizeErr != nil {
@@ -654,6 +707,28 @@ func (a *sessionAgent) createUserMessage(ctx context.Context, call SessionAgentC
 	return msg, nil
 }
 
+// addLimitMessage tells the user the request was stopped because it hit a
+// limit, so whatever came before is partial.
+func (a *sessionAgent) addLimitMessage(ctx context.Context, call SessionAgentCall, reason message.FinishReason, text, title string) error {
+	_, err := a.messages.Create(ctx, call.SessionID, message.CreateMessageParams{
+		Role: message.Assistant,
+		Parts: []message.ContentPart{
+			message.TextContent{Text: text},
+			message.Finish{
+				Reason:  reason,
+				Time:    time.Now().Unix(),
+				Message: title,
+			},
+		},
+		Model:    a.largeModel.ModelCfg.Model,
+		Provider: a.largeModel.ModelCfg.Provider,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create limit message: %w", err)
+	}
+	return nil
+}
+
 func (a *sessionAgent) preparePrompt(msgs []message.Message, attachments ...message.Attachment) ([]fantasy.Message, []fantasy.FilePart) {
 	var history []fantasy.Message
 	for _, m := range msgs {
@@ -761,6 +836,7 @@ func (a *sessionAgent) generateTitle(ctx context.Context, session *session.Sessi
 		}
 	}
 
+	a.refreshSessionCost(ctx, session)
 	a.updateSessionUsage(a.smallModel, session, resp.TotalUsage, openrouterCost)
 	_, saveErr := a.sessions.Save(ctx, *session)
 	if saveErr != nil {
@@ -782,6 +858,18 @@ func (a *sessionAgent) openrouterCost(metadata fantasy.ProviderMetadata) *float6
 	return &opts.Usage.Cost
 }
 
+// refreshSessionCost reloads the cost of session from the store. Sub-agents
+// add their cost to the stored parent session, so the in-memory copy would
+// otherwise miss it and overwrite it on the next save.
+func (a *sessionAgent) refreshSessionCost(ctx context.Context, session *session.Session) {
+	stored, err := a.sessions.Get(ctx, session.ID)
+	if err != nil {
+		slog.Error("failed to reload session cost", "error", err)
+		return
+	}
+	session.Cost = stored.Cost
+}
+
 func (a *sessionAgent) updateSessionUsage(model Model, session *session.Session, usage fantasy.Usage, overrideCost *float64) {
 	modelConfig := model.CatwalkCfg
 	cost := modelConfig.CostPer1MInCached/1e6*float64(usage.CacheCreationTokens) +
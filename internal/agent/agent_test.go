@@ -1,10 +1,13 @@
 package agent
 
 import (
+	"context"
+	"fmt"
 	"os"
 	"path/filepath"
 	"runtime"
 	"strings"
+	"sync/atomic"
 	"testing"
 
 	"charm.land/fantasy"
@@ -615,3 +618,135 @@ func TestCoderAgent(t *testing.T) {
 		})
 	}
 }
+
+// loopingModel is a fake language model that asks for the echo tool on
+// every call that offers tools and answers with plain text otherwise, as
+// when generating a title.
+type loopingModel struct {
+	toolCalls atomic.Int32
+}
+
+func (m *loopingModel) Generate(context.Context, fantasy.Call) (*fantasy.Response, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func (m *loopingModel) Stream(_ context.Context, call fantasy.Call) (fantasy.StreamResponse, error) {
+	if len(call.Tools) == 0 {
+		return func(yield func(fantasy.StreamPart) bool) {
+			_ = yield(fantasy.StreamPart{Type: fantasy.StreamPartTypeTextStart, ID: "0"}) &&
+				yield(fantasy.StreamPart{Type: fantasy.StreamPartTypeTextDelta, ID: "0", Delta: "Title"}) &&
+				yield(fantasy.StreamPart{Type: fantasy.StreamPartTypeTextEnd, ID: "0"}) &&
+				yield(fantasy.StreamPart{Type: fantasy.StreamPartTypeFinish, FinishReason: fantasy.FinishReasonStop})
+		}, nil
+	}
+	id := fmt.Sprintf("call_%d", m.toolCalls.Add(1))
+	return func(yield func(fantasy.StreamPart) bool) {
+		_ = yield(fantasy.StreamPart{Type: fantasy.StreamPartTypeToolCall, ID: id, ToolCallName: "echo", ToolCallInput: "{}"}) &&
+			yield(fantasy.StreamPart{Type: fantasy.StreamPartTypeFinish, FinishReason: fantasy.FinishReasonToolCalls})
+	}, nil
+}
+
+func (m *loopingModel) GenerateObject(context.Context, fantasy.ObjectCall) (*fantasy.ObjectResponse, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func (m *loopingModel) StreamObject(context.Context, fantasy.ObjectCall) (fantasy.ObjectStreamResponse, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func (m *loopingModel) Provider() string { return "fake" }
+func (m *loopingModel) Model() string    { return "looping" }
+
+func TestTurnLimit(t *testing.T) {
+	env := testEnv(t)
+	large := &loopingModel{}
+	echo := fantasy.NewAgentTool("echo", "Echo", func(context.Context, struct{}, fantasy.ToolCall) (fantasy.ToolResponse, error) {
+		return fantasy.NewTextResponse("echo"), nil
+	})
+	agent := testSessionAgent(env, large, &loopingModel{}, "You are a test agent.", echo)
+
+	session, err := env.sessions.Create(t.Context(), "New Session")
+	require.NoError(t, err)
+
+	_, err = agent.Run(t.Context(), SessionAgentCall{
+		Prompt:          "Loop forever",
+		SessionID:       session.ID,
+		MaxOutputTokens: 1000,
+		MaxTurns:        3,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int32(3), large.toolCalls.Load())
+
+	msgs, err := env.messages.List(t.Context(), session.ID)
+	require.NoError(t, err)
+	last := msgs[len(msgs)-1]
+	require.Equal(t, message.Assistant, last.Role)
+	require.Equal(t, message.FinishReasonTurnLimit, last.FinishReason())
+	require.Contains(t, last.Content().Text, "Stopped after 3 turns due to the turn limit")
+	require.Contains(t, last.Content().Text, "partial")
+}
+
+func TestCostLimit(t *testing.T) {
+	t.Run("refuses to start over the limit", func(t *testing.T) {
+		env := testEnv(t)
+		large := &loopingModel{}
+		agent := testSessionAgent(env, large, &loopingModel{}, "You are a test agent.")
+
+		session, err := env.sessions.Create(t.Context(), "New Session")
+		require.NoError(t, err)
+		session.Cost = 5
+		_, err = env.sessions.Save(t.Context(), session)
+		require.NoError(t, err)
+
+		_, err = agent.Run(t.Context(), SessionAgentCall{
+			Prompt:          "Loop forever",
+			SessionID:       session.ID,
+			MaxOutputTokens: 1000,
+			CostLimit:       5,
+		})
+		require.ErrorIs(t, err, ErrSessionCostLimit)
+		require.Zero(t, large.toolCalls.Load())
+	})
+
+	t.Run("pauses once the limit is reached", func(t *testing.T) {
+		env := testEnv(t)
+		large := &loopingModel{}
+		// Like a sub-agent, the tool adds its cost to the stored session.
+		spend := fantasy.NewAgentTool("echo", "Echo", func(ctx context.Context, _ struct{}, _ fantasy.ToolCall) (fantasy.ToolResponse, error) {
+			s, err := env.sessions.Get(ctx, tools.GetSessionFromContext(ctx))
+			if err != nil {
+				return fantasy.ToolResponse{}, err
+			}
+			s.Cost++
+			if _, err := env.sessions.Save(ctx, s); err != nil {
+				return fantasy.ToolResponse{}, err
+			}
+			return fantasy.NewTextResponse("echo"), nil
+		})
+		agent := testSessionAgent(env, large, &loopingModel{}, "You are a test agent.", spend)
+
+		session, err := env.sessions.Create(t.Context(), "New Session")
+		require.NoError(t, err)
+
+		_, err = agent.Run(t.Context(), SessionAgentCall{
+			Prompt:          "Loop forever",
+			SessionID:       session.ID,
+			MaxOutputTokens: 1000,
+			MaxTurns:        10,
+			CostLimit:       2.5,
+		})
+		require.NoError(t, err)
+		require.Equal(t, int32(3), large.toolCalls.Load())
+
+		session, err = env.sessions.Get(t.Context(), session.ID)
+		require.NoError(t, err)
+		require.Equal(t, 3.0, session.Cost)
+
+		msgs, err := env.messages.List(t.Context(), session.ID)
+		require.NoError(t, err)
+		last := msgs[len(msgs)-1]
+		require.Equal(t, message.Assistant, last.Role)
+		require.Equal(t, message.FinishReasonCostLimit, last.FinishReason())
+		require.Contains(t, last.Content().Text, "session cost reached the $2.50 limit")
+	})
+}
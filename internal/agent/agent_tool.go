@@ -43,7 +43,7 @@ func (c *coordinator) agentTool(ctx context.Context) (fantasy.AgentTool, error)
 		string(agentToolDescription),
 		func(ctx context.Context, params AgentParams, call fantasy.ToolCall) (fantasy.ToolResponse, error) {
 			if params.Prompt == "" {
-				return fantasy.NewTextErrorResponse("prompt is required"), nil
+				return tools.NewErrorResponse(tools.ErrorKindInvalidArgs, "prompt is required"), nil
 			}
 
 			sessionID := tools.GetSessionFromContext(ctx)
@@ -57,6 +57,11 @@ func (c *coordinator) agentTool(ctx context.Context) (fantasy.AgentTool, error)
 			}
 
 			agentToolSessionID := c.sessions.CreateAgentToolSessionID(agentMessageID, call.ID)
+			costLimit, err := c.subAgentCostLimit(ctx, sessionID)
+			if err != nil {
+				return tools.NewErrorResponse(tools.ErrorKindLimitExceeded, err.Error()), nil
+			}
+
 			session, err := c.sessions.CreateTaskSession(ctx, agentToolSessionID, sessionID, "New Agent Session")
 			if err != nil {
 				return fantasy.ToolResponse{}, fmt.Errorf("error creating session: %s", err)
@@ -81,9 +86,11 @@ func (c *coordinator) agentTool(ctx context.Context) (fantasy.AgentTool, error)
 				TopK:             model.ModelCfg.TopK,
 				FrequencyPenalty: model.ModelCfg.FrequencyPenalty,
 				PresencePenalty:  model.ModelCfg.PresencePenalty,
+				MaxTurns:         c.SessionMaxTurns(ctx, sessionID),
+				CostLimit:        costLimit,
 			})
 			if err != nil {
-				return fantasy.NewTextErrorResponse("error generating response"), nil
+				return tools.NewErrorResponse(tools.ErrorKindInternal, "error generating response"), nil
 			}
 			updatedSession, err := c.sessions.Get(ctx, session.ID)
 			if err != nil {
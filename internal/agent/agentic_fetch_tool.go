@@ -56,23 +56,20 @@ var agenticFetchPromptTmpl []byte
 
 func (c *coordinator) agenticFetchTool(_ context.Context, client *http.Client) (fantasy.AgentTool, error) {
 	if client == nil {
-		client = &http.Client{
-			Timeout: 30 * time.Second,
-			Transport: &http.Transport{
-				MaxIdleConns:        100,
-				MaxIdleConnsPerHost: 10,
-				IdleConnTimeout:     90 * time.Second,
-			},
-		}
+		client = tools.NewHTTPClient(30 * time.Second)
 	}
 
 	return fantasy.NewAgentTool(
 		tools.AgenticFetchToolName,
 		string(agenticFetchToolDescription),
 		func(ctx context.Context, params tools.AgenticFetchParams, call fantasy.ToolCall) (fantasy.ToolResponse, error) {
+			if err := tools.CheckNetworkPolicy(ctx, c.cfg.Tools.HTTP, tools.AgenticFetchToolName); err != nil {
+				return tools.NewErrorResponse(tools.ErrorKindNetworkPolicy, err.Error()), nil
+			}
+
 			validationResult, err := validateAgenticFetchParams(ctx, params)
 			if err != nil {
-				return fantasy.NewTextErrorResponse(err.Error()), nil
+				return tools.NewErrorResponse(tools.ErrorKindInvalidArgs, err.Error()), nil
 			}
 
 			p := c.permissions.Request(
@@ -91,14 +88,21 @@ func (c *coordinator) agenticFetchTool(_ context.Context, client *http.Client) (
 				return fantasy.ToolResponse{}, permission.ErrorPermissionDenied
 			}
 
-			content, err := tools.FetchURLAndConvert(ctx, client, params.URL)
+			content, err := tools.FetchURLAndConvert(ctx, client, c.cfg.Tools.HTTP, params.URL)
 			if err != nil {
-				return fantasy.NewTextErrorResponse(fmt.Sprintf("Failed to fetch URL: %s", err)), nil
+				kind := tools.ErrorKindNetwork
+				switch {
+				case errors.Is(err, tools.ErrDisallowedByRobots):
+					kind = tools.ErrorKindAccessDenied
+				case errors.Is(err, tools.ErrCrawlDelay):
+					kind = tools.ErrorKindRateLimited
+				}
+				return tools.NewErrorResponse(kind, fmt.Sprintf("Failed to fetch URL: %s", err)), nil
 			}
 
 			tmpDir, err := os.MkdirTemp(c.cfg.Options.DataDirectory, "crush-fetch-*")
 			if err != nil {
-				return fantasy.NewTextErrorResponse(fmt.Sprintf("Failed to create temporary directory: %s", err)), nil
+				return tools.NewErrorResponse(tools.ErrorKindInternal, fmt.Sprintf("Failed to create temporary directory: %s", err)), nil
 			}
 			defer os.RemoveAll(tmpDir)
 
@@ -108,13 +112,13 @@ func (c *coordinator) agenticFetchTool(_ context.Context, client *http.Client) (
 			if hasLargeContent {
 				tempFile, err := os.CreateTemp(tmpDir, "page-*.md")
 				if err != nil {
-					return fantasy.NewTextErrorResponse(fmt.Sprintf("Failed to create temporary file: %s", err)), nil
+					return tools.NewErrorResponse(tools.ErrorKindInternal, fmt.Sprintf("Failed to create temporary file: %s", err)), nil
 				}
 				tempFilePath := tempFile.Name()
 
 				if _, err := tempFile.WriteString(content); err != nil {
 					tempFile.Close()
-					return fantasy.NewTextErrorResponse(fmt.Sprintf("Failed to write content to file: %s", err)), nil
+					return tools.NewErrorResponse(tools.ErrorKindInternal, fmt.Sprintf("Failed to write content to file: %s", err)), nil
 				}
 				tempFile.Close()
 
@@ -147,7 +151,7 @@ func (c *coordinator) agenticFetchTool(_ context.Context, client *http.Client) (
 				return fantasy.ToolResponse{}, errors.New("small model provider not configured")
 			}
 
-			webFetchTool := tools.NewWebFetchTool(tmpDir, client)
+			webFetchTool := tools.NewWebFetchTool(tmpDir, c.cfg.Tools.HTTP, client)
 			fetchTools := []fantasy.AgentTool{
 				webFetchTool,
 				tools.NewGlobTool(tmpDir),
@@ -167,6 +171,11 @@ func (c *coordinator) agenticFetchTool(_ context.Context, client *http.Client) (
 				Tools:                fetchTools,
 			})
 
+			costLimit, err := c.subAgentCostLimit(ctx, validationResult.SessionID)
+			if err != nil {
+				return tools.NewErrorResponse(tools.ErrorKindLimitExceeded, err.Error()), nil
+			}
+
 			agentToolSessionID := c.sessions.CreateAgentToolSessionID(validationResult.AgentMessageID, call.ID)
 			session, err := c.sessions.CreateTaskSession(ctx, agentToolSessionID, validationResult.SessionID, "Fetch Analysis")
 			if err != nil {
@@ -191,9 +200,11 @@ func (c *coordinator) agenticFetchTool(_ context.Context, client *http.Client) (
 				TopK:             small.ModelCfg.TopK,
 				FrequencyPenalty: small.ModelCfg.FrequencyPenalty,
 				PresencePenalty:  small.ModelCfg.PresencePenalty,
+				MaxTurns:         c.SessionMaxTurns(ctx, validationResult.SessionID),
+				CostLimit:        costLimit,
 			})
 			if err != nil {
-				return fantasy.NewTextErrorResponse("error generating response"), nil
+				return tools.NewErrorResponse(tools.ErrorKindInternal, "error generating response"), nil
 			}
 
 			updatedSession, err := c.sessions.Get(ctx, session.ID)
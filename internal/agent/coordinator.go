@@ -50,6 +50,12 @@ type Coordinator interface {
 	QueuedPrompts(sessionID string) int
 	ClearQueue(sessionID string)
 	Summarize(context.Context, string) error
+	Worklog(ctx context.Context, activity string) (string, error)
+	InspectPrompt(ctx context.Context, sessionID string) (PromptBreakdown, error)
+	SessionCostLimit(sessionID string) float64
+	SetSessionCostLimit(sessionID string, limit float64)
+	SessionMaxTurns(ctx context.Context, sessionID string) int
+	SetSessionMaxTurns(sessionID string, turns int)
 	Model() Model
 	UpdateModels(ctx context.Context) error
 }
@@ -65,6 +71,14 @@ type coordinator struct {
 	currentAgent SessionAgent
 	agents       map[string]SessionAgent
 
+	// costLimits holds per-session overrides of the session_cost_limit option.
+	costLimits *csync.Map[string, float64]
+	// turnLimits holds per-session overrides of the max_turns options.
+	turnLimits *csync.Map[string, int]
+	// requestLimiters holds the request limiter of each provider with
+	// max_concurrent_requests set, shared by every agent using it.
+	requestLimiters *csync.Map[string, *requestLimiter]
+
 	readyWg errgroup.Group
 }
 
@@ -78,13 +92,16 @@ func NewCoordinator(
 	lspClients *csync.Map[string, *lsp.Client],
 ) (Coordinator, error) {
 	c := &coordinator{
-		cfg:         cfg,
-		sessions:    sessions,
-		messages:    messages,
-		permissions: permissions,
-		history:     history,
-		lspClients:  lspClients,
-		agents:      make(map[string]SessionAgent),
+		cfg:             cfg,
+		sessions:        sessions,
+		messages:        messages,
+		permissions:     permissions,
+		history:         history,
+		lspClients:      lspClients,
+		agents:          make(map[string]SessionAgent),
+		costLimits:      csync.NewMap[string, float64](),
+		turnLimits:      csync.NewMap[string, int](),
+		requestLimiters: csync.NewMap[string, *requestLimiter](),
 	}
 
 	agentCfg, ok := cfg.Agents[config.AgentCoder]
@@ -141,6 +158,8 @@ func (c *coordinator) Run(ctx context.Context, sessionID string, prompt string,
 		TopK:             topK,
 		FrequencyPenalty: freqPenalty,
 		PresencePenalty:  presPenalty,
+		MaxTurns:         c.SessionMaxTurns(ctx, sessionID),
+		CostLimit:        c.SessionCostLimit(sessionID),
 	})
 }
 
@@ -339,14 +358,15 @@ func (c *coordinator) buildTools(ctx context.Context, agent config.Agent) ([]fan
 		tools.NewBashTool(c.permissions, c.cfg.WorkingDir(), c.cfg.Options.Attribution, modelName),
 		tools.NewJobOutputTool(),
 		tools.NewJobKillTool(),
-		tools.NewDownloadTool(c.permissions, c.cfg.WorkingDir(), nil),
+		tools.NewCodeSearchTool(c.cfg.WorkingDir()),
+		tools.NewDownloadTool(c.permissions, c.cfg.WorkingDir(), c.cfg.Tools.HTTP, nil),
 		tools.NewEditTool(c.lspClients, c.permissions, c.history, c.cfg.WorkingDir()),
 		tools.NewMultiEditTool(c.lspClients, c.permissions, c.history, c.cfg.WorkingDir()),
-		tools.NewFetchTool(c.permissions, c.cfg.WorkingDir(), nil),
+		tools.NewFetchTool(c.permissions, c.cfg.WorkingDir(), c.cfg.Tools.HTTP, nil),
 		tools.NewGlobTool(c.cfg.WorkingDir()),
 		tools.NewGrepTool(c.cfg.WorkingDir()),
 		tools.NewLsTool(c.permissions, c.cfg.WorkingDir(), c.cfg.Tools.Ls),
-		tools.NewSourcegraphTool(nil),
+		tools.NewSourcegraphTool(c.cfg.Tools.HTTP, nil),
 		tools.NewViewTool(c.lspClients, c.permissions, c.cfg.WorkingDir()),
 		tools.NewWriteTool(c.lspClients, c.permissions, c.history, c.cfg.WorkingDir()),
 	)
@@ -463,6 +483,9 @@ func (c *coordinator) buildAgentModels(ctx context.Context) (Model, Model, error
 		return Model{}, Model{}, err
 	}
 
+	largeModel = c.limitModel(largeProviderCfg, largeModel)
+	smallModel = c.limitModel(smallProviderCfg, smallModel)
+
 	return Model{
 			Model:      largeModel,
 			CatwalkCfg: *largeCatwalkModel,
@@ -474,6 +497,23 @@ func (c *coordinator) buildAgentModels(ctx context.Context) (Model, Model, error
 		}, nil
 }
 
+// limitModel wraps model so that it shares the request limit of its provider.
+// Models of providers without a limit are returned as-is.
+func (c *coordinator) limitModel(providerCfg config.ProviderConfig, model fantasy.LanguageModel) fantasy.LanguageModel {
+	limit := providerCfg.MaxConcurrentRequests
+	if limit <= 0 {
+		return model
+	}
+	limiter := c.requestLimiters.GetOrSet(providerCfg.ID, func() *requestLimiter {
+		return newRequestLimiter(limit)
+	})
+	if limiter.Limit() != limit {
+		limiter = newRequestLimiter(limit)
+		c.requestLimiters.Set(providerCfg.ID, limiter)
+	}
+	return &limitedModel{LanguageModel: model, limiter: limiter}
+}
+
 func (c *coordinator) buildAnthropicProvider(baseURL, apiKey string, headers map[string]string) (fantasy.Provider, error) {
 	hasBearerAuth := false
 	for key := range headers {
@@ -765,3 +805,111 @@ func (c *coordinator) Summarize(ctx context.Context, sessionID string) error {
 	}
 	return c.currentAgent.Summarize(ctx, sessionID, getProviderOptions(c.currentAgent.Model(), providerCfg))
 }
+
+// SessionCostLimit returns the cost limit in USD for the given session: its
+// override if one was set, the session_cost_limit option otherwise.
+func (c *coordinator) SessionCostLimit(sessionID string) float64 {
+	if limit, ok := c.costLimits.Get(sessionID); ok {
+		return limit
+	}
+	return c.cfg.Options.SessionCostLimit
+}
+
+// SetSessionCostLimit overrides the cost limit for the given session, e.g.
+// to let a paused session continue. A limit of zero removes the cap.
+func (c *coordinator) SetSessionCostLimit(sessionID string, limit float64) {
+	c.costLimits.Set(sessionID, limit)
+}
+
+// SessionMaxTurns returns the turn limit for the given session: its override
+// if one was set, otherwise the max_turns option for the kind of session in
+// ctx.
+func (c *coordinator) SessionMaxTurns(ctx context.Context, sessionID string) int {
+	if turns, ok := c.turnLimits.Get(sessionID); ok {
+		return turns
+	}
+	return defaultMaxTurns(c.cfg.Options, tools.GetSessionKindFromContext(ctx))
+}
+
+// SetSessionMaxTurns overrides the turn limit for the given session. A limit
+// of zero removes the cap.
+func (c *coordinator) SetSessionMaxTurns(sessionID string, turns int) {
+	c.turnLimits.Set(sessionID, turns)
+}
+
+// defaultMaxTurns returns the configured turn limit for a kind of session,
+// falling back to max_turns when no kind-specific limit is set.
+func defaultMaxTurns(opts *config.Options, kind tools.SessionKind) int {
+	if kind == tools.SessionNonInteractive {
+		return cmp.Or(opts.NonInteractiveMaxTurns, opts.MaxTurns)
+	}
+	return cmp.Or(opts.InteractiveMaxTurns, opts.MaxTurns)
+}
+
+// subAgentCostLimit returns the cost limit for a sub-agent started from the
+// given parent session: whatever is left of the parent's limit, since the
+// sub-agent's cost is added to the parent once it finishes. It returns
+// ErrSessionCostLimit if the parent has nothing left to spend.
+func (c *coordinator) subAgentCostLimit(ctx context.Context, parentSessionID string) (float64, error) {
+	limit := c.SessionCostLimit(parentSessionID)
+	if limit <= 0 {
+		return 0, nil
+	}
+	parent, err := c.sessions.Get(ctx, parentSessionID)
+	if err != nil {
+		return 0, err
+	}
+	remaining := limit - parent.Cost
+	if remaining <= 0 {
+		return 0, fmt.Errorf("%w: $%.2f spent, limit is $%.2f", ErrSessionCostLimit, parent.Cost, limit)
+	}
+	return remaining, nil
+}
+
+// InspectPrompt returns an estimate of how the prompt for the next request
+// in the given session is composed.
+func (c *coordinator) InspectPrompt(ctx context.Context, sessionID string) (PromptBreakdown, error) {
+	if err := c.readyWg.Wait(); err != nil {
+		return PromptBreakdown{}, err
+	}
+	return c.currentAgent.InspectPrompt(ctx, sessionID)
+}
+
+// Worklog generates a human-readable worklog entry from a description of
+// recent activity, using the same model as summarization.
+func (c *coordinator) Worklog(ctx context.Context, activity string) (string, error) {
+	if err := c.readyWg.Wait(); err != nil {
+		return "", err
+	}
+
+	model := c.currentAgent.Model()
+	providerCfg, ok := c.cfg.Providers.Get(model.ModelCfg.Provider)
+	if !ok {
+		return "", errors.New("model provider not configured")
+	}
+
+	agent := fantasy.NewAgent(model.Model,
+		fantasy.WithSystemPrompt(string(worklogPrompt)),
+	)
+	resp, err := agent.Generate(ctx, fantasy.AgentCall{
+		Prompt:          fmt.Sprintf("Write a worklog entry for the following activity:\n\n%s", activity),
+		ProviderOptions: getProviderOptions(model, providerCfg),
+		PrepareStep: func(callContext context.Context, options fantasy.PrepareStepFunctionOptions) (_ context.Context, prepared fantasy.PrepareStepResult, err error) {
+			prepared.Messages = options.Messages
+			if providerCfg.SystemPromptPrefix != "" {
+				prepared.Messages = append([]fantasy.Message{fantasy.NewSystemMessage(providerCfg.SystemPromptPrefix)}, prepared.Messages...)
+			}
+			return callContext, prepared, nil
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to generate worklog: %w", err)
+	}
+
+	entry := resp.Response.Content.Text()
+	// Remove thinking tags if present.
+	if idx := strings.Index(entry, "</think>"); idx > 0 {
+		entry = entry[idx+len("</think>"):]
+	}
+	return strings.TrimSpace(entry), nil
+}
@@ -0,0 +1,107 @@
+package agent
+
+import (
+	"context"
+	"testing"
+
+	"github.com/charmbracelet/crush/internal/agent/tools"
+	"github.com/charmbracelet/crush/internal/config"
+	"github.com/charmbracelet/crush/internal/csync"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessionMaxTurns(t *testing.T) {
+	t.Parallel()
+
+	interactive := t.Context()
+	nonInteractive := context.WithValue(t.Context(), tools.SessionKindContextKey, tools.SessionNonInteractive)
+
+	t.Run("falls back to max_turns", func(t *testing.T) {
+		t.Parallel()
+
+		c := &coordinator{
+			cfg:        &config.Config{Options: &config.Options{MaxTurns: 25}},
+			turnLimits: csync.NewMap[string, int](),
+		}
+		require.Equal(t, 25, c.SessionMaxTurns(interactive, "s1"))
+		require.Equal(t, 25, c.SessionMaxTurns(nonInteractive, "s1"))
+	})
+
+	t.Run("uses the limit for the session kind", func(t *testing.T) {
+		t.Parallel()
+
+		c := &coordinator{
+			cfg: &config.Config{Options: &config.Options{
+				MaxTurns:               25,
+				InteractiveMaxTurns:    10,
+				NonInteractiveMaxTurns: 50,
+			}},
+			turnLimits: csync.NewMap[string, int](),
+		}
+		require.Equal(t, 10, c.SessionMaxTurns(interactive, "s1"))
+		require.Equal(t, 50, c.SessionMaxTurns(nonInteractive, "s1"))
+	})
+
+	t.Run("session override wins", func(t *testing.T) {
+		t.Parallel()
+
+		c := &coordinator{
+			cfg: &config.Config{Options: &config.Options{
+				MaxTurns:            25,
+				InteractiveMaxTurns: 10,
+			}},
+			turnLimits: csync.NewMap[string, int](),
+		}
+		c.SetSessionMaxTurns("s1", 3)
+		require.Equal(t, 3, c.SessionMaxTurns(interactive, "s1"))
+		require.Equal(t, 10, c.SessionMaxTurns(interactive, "s2"))
+
+		c.SetSessionMaxTurns("s1", 0)
+		require.Equal(t, 0, c.SessionMaxTurns(interactive, "s1"))
+	})
+}
+
+func TestSessionCostLimit(t *testing.T) {
+	t.Parallel()
+
+	c := &coordinator{
+		cfg:        &config.Config{Options: &config.Options{SessionCostLimit: 5}},
+		costLimits: csync.NewMap[string, float64](),
+	}
+	require.Equal(t, 5.0, c.SessionCostLimit("s1"))
+
+	c.SetSessionCostLimit("s1", 10)
+	require.Equal(t, 10.0, c.SessionCostLimit("s1"))
+	require.Equal(t, 5.0, c.SessionCostLimit("s2"))
+
+	c.SetSessionCostLimit("s1", 0)
+	require.Zero(t, c.SessionCostLimit("s1"))
+}
+
+func TestSubAgentCostLimit(t *testing.T) {
+	env := testEnv(t)
+	c := &coordinator{
+		cfg:        &config.Config{Options: &config.Options{SessionCostLimit: 5}},
+		sessions:   env.sessions,
+		costLimits: csync.NewMap[string, float64](),
+	}
+
+	parent, err := env.sessions.Create(t.Context(), "Parent")
+	require.NoError(t, err)
+	parent.Cost = 3.5
+	_, err = env.sessions.Save(t.Context(), parent)
+	require.NoError(t, err)
+
+	limit, err := c.subAgentCostLimit(t.Context(), parent.ID)
+	require.NoError(t, err)
+	require.InDelta(t, 1.5, limit, 1e-9)
+
+	c.SetSessionCostLimit(parent.ID, 3.5)
+	_, err = c.subAgentCostLimit(t.Context(), parent.ID)
+	require.ErrorIs(t, err, ErrSessionCostLimit)
+
+	c.SetSessionCostLimit(parent.ID, 0)
+	limit, err = c.subAgentCostLimit(t.Context(), parent.ID)
+	require.NoError(t, err)
+	require.Zero(t, limit)
+}
@@ -10,6 +10,7 @@ var (
 	ErrSessionBusy      = errors.New("session is currently processing another request")
 	ErrEmptyPrompt      = errors.New("prompt is empty")
 	ErrSessionMissing   = errors.New("session id is missing")
+	ErrSessionCostLimit = errors.New("session cost limit reached")
 )
 
 func isCancelledErr(err error) bool {
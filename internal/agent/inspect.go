@@ -0,0 +1,228 @@
+package agent
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"image"
+	"path/filepath"
+	"strings"
+
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+
+	"github.com/charmbracelet/crush/internal/message"
+)
+
+// PromptComponentKind identifies which part of the prompt a component is.
+type PromptComponentKind string
+
+const (
+	PromptComponentSystem  PromptComponentKind = "system"
+	PromptComponentSummary PromptComponentKind = "summary"
+	PromptComponentMessage PromptComponentKind = "message"
+	PromptComponentTool    PromptComponentKind = "tool"
+	// PromptComponentAttachment is a file or image sent with a message. Its
+	// token count is a rougher estimate than the others, as providers price
+	// binary content in their own ways.
+	PromptComponentAttachment PromptComponentKind = "attachment"
+)
+
+// PromptComponent is a single part of the prompt sent to the model.
+type PromptComponent struct {
+	Kind   PromptComponentKind
+	Name   string
+	Tokens int64
+}
+
+// PromptBreakdown describes how the prompt for the next request in a session
+// is composed. Token counts are estimates; ReportedTokens is what the provider
+// reported for the last request and can be used to judge how close they are.
+type PromptBreakdown struct {
+	Components     []PromptComponent
+	ReportedTokens int64
+	ContextWindow  int64
+}
+
+// Total returns the estimated number of tokens across all components.
+func (b PromptBreakdown) Total() int64 {
+	var total int64
+	for _, c := range b.Components {
+		total += c.Tokens
+	}
+	return total
+}
+
+// TotalFor returns the estimated number of tokens for a kind of component.
+func (b PromptBreakdown) TotalFor(kind PromptComponentKind) int64 {
+	var total int64
+	for _, c := range b.Components {
+		if c.Kind == kind {
+			total += c.Tokens
+		}
+	}
+	return total
+}
+
+// estimateTokens approximates the token count of s using the common rule of
+// thumb of four characters per token.
+func estimateTokens(s string) int64 {
+	return int64((len(s) + 3) / 4)
+}
+
+const (
+	// maxImageTokens is roughly what providers charge for an image once it
+	// has been scaled down to their maximum size.
+	maxImageTokens = 1600
+	// pixelsPerImageToken is how many pixels of an image make up one token.
+	pixelsPerImageToken = 750
+	// attachmentTokens is used for binary attachments that are neither text
+	// nor an image we can measure.
+	attachmentTokens = 1600
+)
+
+// estimateAttachmentTokens approximates the token count of an attachment.
+// Text is counted like any other text, images by their dimensions and other
+// files with a flat per-attachment estimate, since providers don't bill
+// binary content by its size.
+func estimateAttachmentTokens(mimeType string, data []byte) int64 {
+	switch {
+	case strings.HasPrefix(mimeType, "text/"):
+		return estimateTokens(string(data))
+	case strings.HasPrefix(mimeType, "image/"):
+		cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+		if err != nil {
+			return maxImageTokens
+		}
+		return min(max(int64(cfg.Width*cfg.Height/pixelsPerImageToken), 1), maxImageTokens)
+	default:
+		return attachmentTokens
+	}
+}
+
+func (a *sessionAgent) InspectPrompt(ctx context.Context, sessionID string) (PromptBreakdown, error) {
+	currentSession, err := a.sessions.Get(ctx, sessionID)
+	if err != nil {
+		return PromptBreakdown{}, fmt.Errorf("failed to get session: %w", err)
+	}
+	msgs, err := a.getSessionMessages(ctx, currentSession)
+	if err != nil {
+		return PromptBreakdown{}, fmt.Errorf("failed to get session messages: %w", err)
+	}
+
+	breakdown := PromptBreakdown{
+		ReportedTokens: currentSession.PromptTokens,
+		ContextWindow:  int64(a.largeModel.CatwalkCfg.ContextWindow),
+	}
+	if a.systemPromptPrefix != "" {
+		breakdown.Components = append(breakdown.Components, PromptComponent{
+			Kind:   PromptComponentSystem,
+			Name:   "System prompt prefix",
+			Tokens: estimateTokens(a.systemPromptPrefix),
+		})
+	}
+	breakdown.Components = append(breakdown.Components, PromptComponent{
+		Kind:   PromptComponentSystem,
+		Name:   "System prompt",
+		Tokens: estimateTokens(a.systemPrompt),
+	})
+
+	for _, m := range msgs {
+		if len(m.Parts) == 0 {
+			continue
+		}
+		component := PromptComponent{
+			Kind:   PromptComponentMessage,
+			Name:   messageComponentName(m),
+			Tokens: estimateMessageTokens(m),
+		}
+		if m.ID == currentSession.SummaryMessageID {
+			component.Kind = PromptComponentSummary
+			component.Name = "Summary"
+		}
+		breakdown.Components = append(breakdown.Components, component)
+		breakdown.Components = append(breakdown.Components, attachmentComponents(m)...)
+	}
+
+	for _, tool := range a.tools {
+		info := tool.Info()
+		schema, _ := json.Marshal(info.Parameters)
+		breakdown.Components = append(breakdown.Components, PromptComponent{
+			Kind:   PromptComponentTool,
+			Name:   info.Name,
+			Tokens: estimateTokens(info.Name) + estimateTokens(info.Description) + estimateTokens(string(schema)),
+		})
+	}
+	return breakdown, nil
+}
+
+func messageComponentName(m message.Message) string {
+	switch m.Role {
+	case message.Tool:
+		var names []string
+		for _, tr := range m.ToolResults() {
+			names = append(names, tr.Name)
+		}
+		return "Tool result: " + strings.Join(names, ", ")
+	case message.Assistant:
+		if calls := m.ToolCalls(); len(calls) > 0 {
+			var names []string
+			for _, tc := range calls {
+				names = append(names, tc.Name)
+			}
+			return "Assistant: " + strings.Join(names, ", ")
+		}
+		return "Assistant"
+	default:
+		return "User"
+	}
+}
+
+func estimateMessageTokens(m message.Message) int64 {
+	tokens := estimateTokens(m.Content().Text) + estimateTokens(m.ReasoningContent().Thinking)
+	for _, tc := range m.ToolCalls() {
+		tokens += estimateTokens(tc.Name) + estimateTokens(tc.Input)
+	}
+	for _, tr := range m.ToolResults() {
+		tokens += estimateTokens(tr.Content)
+		if tr.MIMEType == "" {
+			tokens += estimateTokens(tr.Data)
+		}
+	}
+	return tokens
+}
+
+// attachmentComponents returns a component for each file or image sent with
+// m, including images returned by tools.
+func attachmentComponents(m message.Message) []PromptComponent {
+	var components []PromptComponent
+	for _, b := range m.BinaryContent() {
+		name := b.MIMEType
+		if b.Path != "" {
+			name = filepath.Base(b.Path)
+		}
+		components = append(components, PromptComponent{
+			Kind:   PromptComponentAttachment,
+			Name:   "Attachment: " + name,
+			Tokens: estimateAttachmentTokens(b.MIMEType, b.Data),
+		})
+	}
+	for _, tr := range m.ToolResults() {
+		if tr.MIMEType == "" || tr.Data == "" {
+			continue
+		}
+		data, err := base64.StdEncoding.DecodeString(tr.Data)
+		if err != nil {
+			data = []byte(tr.Data)
+		}
+		components = append(components, PromptComponent{
+			Kind:   PromptComponentAttachment,
+			Name:   fmt.Sprintf("Attachment: %s (%s)", tr.MIMEType, tr.Name),
+			Tokens: estimateAttachmentTokens(tr.MIMEType, data),
+		})
+	}
+	return components
+}
@@ -0,0 +1,100 @@
+package agent
+
+import (
+	"bytes"
+	"encoding/base64"
+	"image"
+	"image/png"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/charmbracelet/crush/internal/message"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEstimateTokens(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, int64(0), estimateTokens(""))
+	require.Equal(t, int64(1), estimateTokens("abc"))
+	require.Equal(t, int64(1), estimateTokens("abcd"))
+	require.Equal(t, int64(2), estimateTokens("abcde"))
+}
+
+func TestPromptBreakdownTotals(t *testing.T) {
+	t.Parallel()
+
+	b := PromptBreakdown{
+		Components: []PromptComponent{
+			{Kind: PromptComponentSystem, Name: "System prompt", Tokens: 100},
+			{Kind: PromptComponentSummary, Name: "Summary", Tokens: 40},
+			{Kind: PromptComponentMessage, Name: "User", Tokens: 10},
+			{Kind: PromptComponentMessage, Name: "Assistant", Tokens: 20},
+			{Kind: PromptComponentTool, Name: "bash", Tokens: 300},
+		},
+	}
+	require.Equal(t, int64(470), b.Total())
+	require.Equal(t, int64(30), b.TotalFor(PromptComponentMessage))
+	require.Equal(t, int64(0), PromptBreakdown{}.TotalFor(PromptComponentTool))
+}
+
+func TestEstimateAttachmentTokens(t *testing.T) {
+	t.Parallel()
+
+	var small bytes.Buffer
+	require.NoError(t, png.Encode(&small, image.NewRGBA(image.Rect(0, 0, 300, 200))))
+	var large bytes.Buffer
+	require.NoError(t, png.Encode(&large, image.NewRGBA(image.Rect(0, 0, 4000, 3000))))
+
+	require.Equal(t, int64(80), estimateAttachmentTokens("image/png", small.Bytes()))
+	require.Equal(t, int64(maxImageTokens), estimateAttachmentTokens("image/png", large.Bytes()))
+	require.Equal(t, int64(maxImageTokens), estimateAttachmentTokens("image/png", []byte("not an image")))
+	require.Equal(t, int64(2), estimateAttachmentTokens("text/plain", []byte("hello")))
+	require.Equal(t, int64(attachmentTokens), estimateAttachmentTokens("application/pdf", make([]byte, 1<<20)))
+}
+
+func TestAttachmentComponents(t *testing.T) {
+	t.Parallel()
+
+	// A large screenshot with noise so it doesn't compress away.
+	img := image.NewRGBA(image.Rect(0, 0, 1024, 768))
+	rng := rand.New(rand.NewPCG(1, 2))
+	for i := range img.Pix {
+		img.Pix[i] = byte(rng.Uint32())
+	}
+	var screenshot bytes.Buffer
+	require.NoError(t, png.Encode(&screenshot, img))
+	require.Greater(t, screenshot.Len(), 100_000)
+
+	m := message.Message{
+		Role: message.User,
+		Parts: []message.ContentPart{
+			message.TextContent{Text: "What is on this screen?"},
+			message.BinaryContent{Path: "/tmp/screenshot.png", MIMEType: "image/png", Data: screenshot.Bytes()},
+		},
+	}
+
+	require.Equal(t, estimateTokens("What is on this screen?"), estimateMessageTokens(m))
+	components := attachmentComponents(m)
+	require.Len(t, components, 1)
+	require.Equal(t, PromptComponentAttachment, components[0].Kind)
+	require.Equal(t, "Attachment: screenshot.png", components[0].Name)
+	require.Equal(t, int64(1024*768/pixelsPerImageToken), components[0].Tokens)
+
+	tool := message.Message{
+		Role: message.Tool,
+		Parts: []message.ContentPart{
+			message.ToolResult{
+				Name:     "view",
+				Content:  "Image read",
+				Data:     base64.StdEncoding.EncodeToString(screenshot.Bytes()),
+				MIMEType: "image/png",
+			},
+		},
+	}
+	require.Equal(t, estimateTokens("Image read"), estimateMessageTokens(tool))
+	components = attachmentComponents(tool)
+	require.Len(t, components, 1)
+	require.Equal(t, "Attachment: image/png (view)", components[0].Name)
+	require.Equal(t, int64(1024*768/pixelsPerImageToken), components[0].Tokens)
+}
@@ -0,0 +1,111 @@
+package agent
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+
+	"charm.land/fantasy"
+)
+
+// requestLimiter bounds the number of requests in flight to a provider.
+// Requests over the limit wait for a free slot in the order they arrived.
+type requestLimiter struct {
+	slots  chan struct{}
+	queued atomic.Int64
+}
+
+func newRequestLimiter(limit int) *requestLimiter {
+	return &requestLimiter{slots: make(chan struct{}, limit)}
+}
+
+// Limit returns the maximum number of requests in flight.
+func (l *requestLimiter) Limit() int {
+	return cap(l.slots)
+}
+
+// Queued returns the number of requests waiting for a free slot.
+func (l *requestLimiter) Queued() int64 {
+	return l.queued.Load()
+}
+
+// acquire waits for a free slot. The returned function releases it and is
+// safe to call more than once.
+func (l *requestLimiter) acquire(ctx context.Context) (func(), error) {
+	select {
+	case l.slots <- struct{}{}:
+	default:
+		queued := l.queued.Add(1)
+		slog.Debug("Waiting for a free provider request slot", "limit", l.Limit(), "queued", queued)
+		select {
+		case l.slots <- struct{}{}:
+			l.queued.Add(-1)
+		case <-ctx.Done():
+			l.queued.Add(-1)
+			return nil, ctx.Err()
+		}
+	}
+	var once sync.Once
+	return func() {
+		once.Do(func() { <-l.slots })
+	}, nil
+}
+
+// limitedModel is a language model whose requests go through a
+// requestLimiter. Streaming requests hold their slot until the stream has
+// been consumed.
+type limitedModel struct {
+	fantasy.LanguageModel
+	limiter *requestLimiter
+}
+
+func (m *limitedModel) Generate(ctx context.Context, call fantasy.Call) (*fantasy.Response, error) {
+	release, err := m.limiter.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return m.LanguageModel.Generate(ctx, call)
+}
+
+func (m *limitedModel) Stream(ctx context.Context, call fantasy.Call) (fantasy.StreamResponse, error) {
+	release, err := m.limiter.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	stream, err := m.LanguageModel.Stream(ctx, call)
+	if err != nil {
+		release()
+		return nil, err
+	}
+	return func(yield func(fantasy.StreamPart) bool) {
+		defer release()
+		stream(yield)
+	}, nil
+}
+
+func (m *limitedModel) GenerateObject(ctx context.Context, call fantasy.ObjectCall) (*fantasy.ObjectResponse, error) {
+	release, err := m.limiter.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return m.LanguageModel.GenerateObject(ctx, call)
+}
+
+func (m *limitedModel) StreamObject(ctx context.Context, call fantasy.ObjectCall) (fantasy.ObjectStreamResponse, error) {
+	release, err := m.limiter.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	stream, err := m.LanguageModel.StreamObject(ctx, call)
+	if err != nil {
+		release()
+		return nil, err
+	}
+	return func(yield func(fantasy.ObjectStreamPart) bool) {
+		defer release()
+		stream(yield)
+	}, nil
+}
@@ -0,0 +1,80 @@
+package agent
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"charm.land/fantasy"
+	"github.com/stretchr/testify/require"
+)
+
+type streamingModel struct {
+	fantasy.LanguageModel
+}
+
+func (streamingModel) Stream(context.Context, fantasy.Call) (fantasy.StreamResponse, error) {
+	return func(yield func(fantasy.StreamPart) bool) {
+		yield(fantasy.StreamPart{Type: fantasy.StreamPartTypeTextDelta, Delta: "hello"})
+	}, nil
+}
+
+func TestRequestLimiter(t *testing.T) {
+	t.Parallel()
+
+	t.Run("queues requests over the limit", func(t *testing.T) {
+		t.Parallel()
+		limiter := newRequestLimiter(1)
+
+		release, err := limiter.acquire(t.Context())
+		require.NoError(t, err)
+
+		acquired := make(chan struct{})
+		go func() {
+			release, err := limiter.acquire(context.Background())
+			if err == nil {
+				release()
+			}
+			close(acquired)
+		}()
+
+		require.Eventually(t, func() bool { return limiter.Queued() == 1 }, time.Second, time.Millisecond)
+		release()
+		release() // releasing twice must not free a second slot
+		<-acquired
+		require.Equal(t, int64(0), limiter.Queued())
+		require.Empty(t, limiter.slots)
+	})
+
+	t.Run("gives up when the context is done", func(t *testing.T) {
+		t.Parallel()
+		limiter := newRequestLimiter(1)
+
+		_, err := limiter.acquire(t.Context())
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(t.Context())
+		cancel()
+		_, err = limiter.acquire(ctx)
+		require.ErrorIs(t, err, context.Canceled)
+		require.Equal(t, int64(0), limiter.Queued())
+	})
+}
+
+func TestLimitedModelStream(t *testing.T) {
+	t.Parallel()
+
+	limiter := newRequestLimiter(1)
+	model := &limitedModel{LanguageModel: streamingModel{}, limiter: limiter}
+
+	stream, err := model.Stream(t.Context(), fantasy.Call{})
+	require.NoError(t, err)
+	require.Len(t, limiter.slots, 1, "slot is held until the stream is consumed")
+
+	var text string
+	for part := range stream {
+		text += part.Delta
+	}
+	require.Equal(t, "hello", text)
+	require.Empty(t, limiter.slots)
+}
@@ -192,7 +192,7 @@ func NewBashTool(permissions permission.Service, workingDir string, attribution
 		string(bashDescription(attribution, modelName)),
 		func(ctx context.Context, params BashParams, call fantasy.ToolCall) (fantasy.ToolResponse, error) {
 			if params.Command == "" {
-				return fantasy.NewTextErrorResponse("missing command"), nil
+				return NewErrorResponse(ErrorKindInvalidArgs, "missing command"), nil
 			}
 
 			// Determine working directory
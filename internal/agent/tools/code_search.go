@@ -0,0 +1,495 @@
+package tools
+
+import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"charm.land/fantasy"
+	"github.com/charmbracelet/crush/internal/fsext"
+)
+
+type CodeSearchParams struct {
+	Query           string `json:"query" description:"The regex pattern or identifier to search for"`
+	Path            string `json:"path,omitempty" description:"The directory to search in. Defaults to the current working directory."`
+	Include         string `json:"include,omitempty" description:"File pattern to include in the search (e.g. \"*.go\", \"*.{ts,tsx}\")"`
+	LiteralText     bool   `json:"literal_text,omitempty" description:"If true, the query will be treated as literal text with special regex characters escaped. Default is false."`
+	DefinitionsOnly bool   `json:"definitions_only,omitempty" description:"If true, only return lines that define a symbol (functions, methods, types, classes). Default is false."`
+}
+
+// CodeSearchResult is a single structured match returned by the code_search
+// tool.
+type CodeSearchResult struct {
+	Path       string `json:"path"`
+	Line       int    `json:"line"`
+	SymbolKind string `json:"symbol_kind,omitempty"`
+	Symbol     string `json:"symbol,omitempty"`
+	Snippet    string `json:"snippet"`
+}
+
+type CodeSearchResponseMetadata struct {
+	NumberOfMatches     int                `json:"number_of_matches"`
+	NumberOfDefinitions int                `json:"number_of_definitions"`
+	Truncated           bool               `json:"truncated"`
+	Results             []CodeSearchResult `json:"results"`
+}
+
+const (
+	CodeSearchToolName = "code_search"
+
+	maxCodeSearchResults        = 100
+	maxCodeSearchMatchesPerFile = 20
+)
+
+//go:embed code_search.md
+var codeSearchDescription []byte
+
+// codeSearchExcludedDirs are directories that almost always hold vendored,
+// generated or build output and are skipped by code_search.
+var codeSearchExcludedDirs = []string{
+	"vendor",
+	"node_modules",
+	"bower_components",
+	"third_party",
+	"Pods",
+	"__pycache__",
+	".venv",
+	"venv",
+}
+
+// codeSearchRootExcludedDirs are build output directories that are only
+// skipped directly under the search root, since nested directories with these
+// names are often real source packages.
+var codeSearchRootExcludedDirs = []string{
+	"dist",
+	"build",
+	"target",
+	"out",
+}
+
+// codeSearchExcludedFiles are file globs for generated or minified files.
+var codeSearchExcludedFiles = []string{
+	"*.pb.go",
+	"*_gen.go",
+	"*.gen.go",
+	"*_generated.go",
+	"*.generated.*",
+	"*.min.js",
+	"*.min.css",
+	"*.map",
+	"*.lock",
+	"go.sum",
+	"package-lock.json",
+	"pnpm-lock.yaml",
+}
+
+// goGeneratedHeader matches the standard "generated code" marker described in
+// https://go.dev/s/generatedcode.
+var goGeneratedHeader = regexp.MustCompile(`(?m)^// Code generated .* DO NOT EDIT\.$`)
+
+type symbolPattern struct {
+	kind string
+	re   *regexp.Regexp
+}
+
+func newSymbolPatterns(pairs ...string) []symbolPattern {
+	patterns := make([]symbolPattern, 0, len(pairs)/2)
+	for i := 0; i+1 < len(pairs); i += 2 {
+		patterns = append(patterns, symbolPattern{
+			kind: pairs[i],
+			re:   regexp.MustCompile(pairs[i+1]),
+		})
+	}
+	return patterns
+}
+
+var (
+	goSymbols = newSymbolPatterns(
+		"method", `^\s*func\s+\([^)]*\)\s*([A-Za-z_]\w*)`,
+		"function", `^\s*func\s+([A-Za-z_]\w*)`,
+		"type", `^\s*type\s+([A-Za-z_]\w*)`,
+		"constant", `^\s*const\s+([A-Za-z_]\w*)`,
+		"variable", `^\s*var\s+([A-Za-z_]\w*)`,
+	)
+	pythonSymbols = newSymbolPatterns(
+		"function", `^\s*(?:async\s+)?def\s+([A-Za-z_]\w*)`,
+		"class", `^\s*class\s+([A-Za-z_]\w*)`,
+	)
+	jsSymbols = newSymbolPatterns(
+		"function", `^\s*(?:export\s+)?(?:default\s+)?(?:async\s+)?function\s*\*?\s*([A-Za-z_$][\w$]*)`,
+		"class", `^\s*(?:export\s+)?(?:default\s+)?(?:abstract\s+)?class\s+([A-Za-z_$][\w$]*)`,
+		"interface", `^\s*(?:export\s+)?interface\s+([A-Za-z_$][\w$]*)`,
+		"type", `^\s*(?:export\s+)?type\s+([A-Za-z_$][\w$]*)\s*(?:<[^>]*>)?\s*=`,
+		"type", `^\s*(?:export\s+)?(?:const\s+)?enum\s+([A-Za-z_$][\w$]*)`,
+		"variable", `^\s*(?:export\s+)?(?:const|let|var)\s+([A-Za-z_$][\w$]*)\s*=`,
+	)
+	rustSymbols = newSymbolPatterns(
+		"function", `^\s*(?:pub(?:\([^)]*\))?\s+)?(?:const\s+)?(?:async\s+)?(?:unsafe\s+)?fn\s+([A-Za-z_]\w*)`,
+		"type", `^\s*(?:pub(?:\([^)]*\))?\s+)?(?:struct|enum|union|type)\s+([A-Za-z_]\w*)`,
+		"interface", `^\s*(?:pub(?:\([^)]*\))?\s+)?trait\s+([A-Za-z_]\w*)`,
+		"module", `^\s*(?:pub(?:\([^)]*\))?\s+)?mod\s+([A-Za-z_]\w*)`,
+	)
+	jvmSymbols = newSymbolPatterns(
+		"class", `^\s*(?:(?:public|private|protected|internal|abstract|final|static|sealed|data|open)\s+)*class\s+([A-Za-z_]\w*)`,
+		"interface", `^\s*(?:(?:public|private|protected|internal|sealed)\s+)*interface\s+([A-Za-z_]\w*)`,
+		"type", `^\s*(?:(?:public|private|protected|internal)\s+)*(?:enum|record|struct)\s+([A-Za-z_]\w*)`,
+		"function", `^\s*(?:(?:public|private|protected|internal|override|suspend)\s+)*fun\s+([A-Za-z_]\w*)`,
+	)
+	rubySymbols = newSymbolPatterns(
+		"function", `^\s*def\s+(?:self\.)?([A-Za-z_]\w*[?!=]?)`,
+		"class", `^\s*class\s+([A-Z]\w*)`,
+		"module", `^\s*module\s+([A-Z]\w*)`,
+	)
+)
+
+var symbolPatternsByExt = map[string][]symbolPattern{
+	".go":   goSymbols,
+	".py":   pythonSymbols,
+	".js":   jsSymbols,
+	".jsx":  jsSymbols,
+	".mjs":  jsSymbols,
+	".cjs":  jsSymbols,
+	".ts":   jsSymbols,
+	".tsx":  jsSymbols,
+	".rs":   rustSymbols,
+	".java": jvmSymbols,
+	".kt":   jvmSymbols,
+	".cs":   jvmSymbols,
+	".rb":   rubySymbols,
+}
+
+// detectSymbol reports the kind and name of the symbol defined on line, if
+// any, based on the language implied by the file extension.
+func detectSymbol(path, line string) (kind, name string) {
+	for _, p := range symbolPatternsByExt[strings.ToLower(filepath.Ext(path))] {
+		if m := p.re.FindStringSubmatch(line); m != nil {
+			return p.kind, m[1]
+		}
+	}
+	return "", ""
+}
+
+type codeSearchMatch struct {
+	path     string
+	lineNum  int
+	lineText string
+}
+
+func NewCodeSearchTool(workingDir string) fantasy.AgentTool {
+	return fantasy.NewAgentTool(
+		CodeSearchToolName,
+		string(codeSearchDescription),
+		func(ctx context.Context, params CodeSearchParams, call fantasy.ToolCall) (fantasy.ToolResponse, error) {
+			if params.Query == "" {
+				return NewErrorResponse(ErrorKindInvalidArgs, "query is required"), nil
+			}
+
+			searchPattern := params.Query
+			if params.LiteralText {
+				searchPattern = escapeRegexPattern(params.Query)
+			}
+
+			searchPath := params.Path
+			if searchPath == "" {
+				searchPath = workingDir
+			}
+
+			results, truncated, err := codeSearch(ctx, searchPattern, searchPath, params.Include, params.DefinitionsOnly, maxCodeSearchResults)
+			if err != nil {
+				return NewErrorResponse(ErrorKindInvalidArgs, fmt.Sprintf("error searching code: %v", err)), nil
+			}
+
+			definitions := 0
+			for _, r := range results {
+				if r.SymbolKind != "" {
+					definitions++
+				}
+			}
+
+			return fantasy.WithResponseMetadata(
+				fantasy.NewTextResponse(formatCodeSearchResults(results, definitions, truncated)),
+				CodeSearchResponseMetadata{
+					NumberOfMatches:     len(results),
+					NumberOfDefinitions: definitions,
+					Truncated:           truncated,
+					Results:             results,
+				},
+			), nil
+		})
+}
+
+func formatCodeSearchResults(results []CodeSearchResult, definitions int, truncated bool) string {
+	if len(results) == 0 {
+		return "No matches found"
+	}
+
+	var output strings.Builder
+	fmt.Fprintf(&output, "Found %d matches (%d definitions)\n", len(results), definitions)
+
+	writeSection := func(title string, definition bool) {
+		wroteTitle := false
+		for _, r := range results {
+			if (r.SymbolKind != "") != definition {
+				continue
+			}
+			if !wroteTitle {
+				fmt.Fprintf(&output, "\n%s:\n", title)
+				wroteTitle = true
+			}
+			if definition {
+				fmt.Fprintf(&output, "  %s:%d [%s %s] %s\n", r.Path, r.Line, r.SymbolKind, r.Symbol, r.Snippet)
+			} else {
+				fmt.Fprintf(&output, "  %s:%d %s\n", r.Path, r.Line, r.Snippet)
+			}
+		}
+	}
+	writeSection("Definitions", true)
+	writeSection("References", false)
+
+	if truncated {
+		output.WriteString("\n(Results are truncated. Consider using a more specific path, include pattern, or query.)")
+	}
+	return output.String()
+}
+
+// codeSearch runs the search, classifies each matching line and returns the
+// results ranked with definitions first.
+func codeSearch(ctx context.Context, pattern, rootPath, include string, definitionsOnly bool, limit int) ([]CodeSearchResult, bool, error) {
+	matches, err := codeSearchWithRipgrep(ctx, pattern, rootPath, include)
+	if err != nil {
+		matches, err = codeSearchWithRegex(pattern, rootPath, include)
+		if err != nil {
+			return nil, false, err
+		}
+	}
+
+	// Symbols whose whole name matches the query are ranked first. The query
+	// may use syntax Go's regexp does not support; in that case symbol names
+	// simply don't get the exact-match boost.
+	exactRegex, _ := searchRegexCache.get("^(?:" + pattern + ")$")
+
+	generated := map[string]bool{}
+	type rankedResult struct {
+		CodeSearchResult
+		rank int
+	}
+	ranked := make([]rankedResult, 0, len(matches))
+	for _, m := range matches {
+		isGenerated, ok := generated[m.path]
+		if !ok {
+			isGenerated = isGeneratedFile(m.path)
+			generated[m.path] = isGenerated
+		}
+		if isGenerated {
+			continue
+		}
+
+		kind, symbol := detectSymbol(m.path, m.lineText)
+		if definitionsOnly && kind == "" {
+			continue
+		}
+
+		rank := 2
+		if kind != "" {
+			rank = 1
+			if exactRegex != nil && exactRegex.MatchString(symbol) {
+				rank = 0
+			}
+		}
+
+		path := m.path
+		if rel, err := filepath.Rel(rootPath, m.path); err == nil && !strings.HasPrefix(rel, "..") {
+			path = rel
+		}
+
+		snippet := strings.TrimSpace(m.lineText)
+		if len(snippet) > maxGrepContentWidth {
+			snippet = snippet[:maxGrepContentWidth] + "..."
+		}
+
+		ranked = append(ranked, rankedResult{
+			CodeSearchResult: CodeSearchResult{
+				Path:       filepath.ToSlash(path),
+				Line:       m.lineNum,
+				SymbolKind: kind,
+				Symbol:     symbol,
+				Snippet:    snippet,
+			},
+			rank: rank,
+		})
+	}
+
+	slices.SortStableFunc(ranked, func(a, b rankedResult) int {
+		return cmp.Or(
+			cmp.Compare(a.rank, b.rank),
+			strings.Compare(a.Path, b.Path),
+			cmp.Compare(a.Line, b.Line),
+		)
+	})
+
+	truncated := len(ranked) > limit
+	if truncated {
+		ranked = ranked[:limit]
+	}
+
+	results := make([]CodeSearchResult, len(ranked))
+	for i, r := range ranked {
+		results[i] = r.CodeSearchResult
+	}
+	return results, truncated, nil
+}
+
+func codeSearchWithRipgrep(ctx context.Context, pattern, path, include string) ([]codeSearchMatch, error) {
+	var ignoreFiles []string
+	for _, ignoreFile := range []string{".gitignore", ".crushignore"} {
+		ignorePath := filepath.Join(path, ignoreFile)
+		if _, err := os.Stat(ignorePath); err == nil {
+			ignoreFiles = append(ignoreFiles, ignorePath)
+		}
+	}
+
+	cmd := getRgCodeSearchCmd(ctx, pattern, path, include, ignoreFiles)
+	if cmd == nil {
+		return nil, fmt.Errorf("ripgrep not found in $PATH")
+	}
+
+	output, err := cmd.Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() == 1 {
+			return []codeSearchMatch{}, nil
+		}
+		return nil, err
+	}
+
+	var matches []codeSearchMatch
+	for line := range bytes.SplitSeq(bytes.TrimSpace(output), []byte{'\n'}) {
+		if len(line) == 0 {
+			continue
+		}
+		var match ripgrepMatch
+		if err := json.Unmarshal(line, &match); err != nil {
+			continue
+		}
+		if match.Type != "match" {
+			continue
+		}
+		matches = append(matches, codeSearchMatch{
+			// ripgrep runs from the search root, so paths are relative to it.
+			path:     filepath.Join(path, match.Data.Path.Text),
+			lineNum:  match.Data.LineNumber,
+			lineText: strings.TrimRight(match.Data.Lines.Text, "\r\n"),
+		})
+	}
+	return matches, nil
+}
+
+func codeSearchWithRegex(pattern, rootPath, include string) ([]codeSearchMatch, error) {
+	regex, err := searchRegexCache.get(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid regex pattern: %w", err)
+	}
+
+	var includePattern *regexp.Regexp
+	if include != "" {
+		includePattern, err = globRegexCache.get(globToRegex(include))
+		if err != nil {
+			return nil, fmt.Errorf("invalid include pattern: %w", err)
+		}
+	}
+
+	walker := fsext.NewFastGlobWalker(rootPath)
+
+	matches := []codeSearchMatch{}
+	err = filepath.Walk(rootPath, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return nil // Skip errors
+		}
+
+		base := filepath.Base(path)
+		if info.IsDir() {
+			if path == rootPath {
+				return nil
+			}
+			// Like ripgrep, only honor ignore files and our own exclusions,
+			// so nested build directories are still searched.
+			if walker.ShouldSkipIgnored(path) || strings.HasPrefix(base, ".") || slices.Contains(codeSearchExcludedDirs, base) {
+				return filepath.SkipDir
+			}
+			if filepath.Dir(path) == filepath.Clean(rootPath) && slices.Contains(codeSearchRootExcludedDirs, base) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if walker.ShouldSkipIgnored(path) || strings.HasPrefix(base, ".") || isExcludedCodeSearchFile(base) {
+			return nil
+		}
+		if includePattern != nil && !includePattern.MatchString(path) {
+			return nil
+		}
+		if !isTextFile(path) {
+			return nil
+		}
+
+		file, err := os.Open(path)
+		if err != nil {
+			return nil // Skip files we can't read
+		}
+		defer file.Close()
+
+		scanner := bufio.NewScanner(file)
+		lineNum, fileMatches := 0, 0
+		for scanner.Scan() && fileMatches < maxCodeSearchMatchesPerFile {
+			lineNum++
+			if regex.MatchString(scanner.Text()) {
+				matches = append(matches, codeSearchMatch{
+					path:     path,
+					lineNum:  lineNum,
+					lineText: scanner.Text(),
+				})
+				fileMatches++
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return matches, nil
+}
+
+func isExcludedCodeSearchFile(name string) bool {
+	for _, glob := range codeSearchExcludedFiles {
+		if ok, _ := filepath.Match(glob, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// isGeneratedFile reports whether a Go file carries the standard generated
+// code header. Other languages rely on the file name globs only.
+func isGeneratedFile(path string) bool {
+	if filepath.Ext(path) != ".go" {
+		return false
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer file.Close()
+
+	header := make([]byte, 1024)
+	n, _ := file.Read(header)
+	return goGeneratedHeader.Match(header[:n])
+}
@@ -0,0 +1,168 @@
+package tools
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectSymbol(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		path string
+		line string
+		kind string
+		name string
+	}{
+		{"main.go", "func (s *Server) Handle(w http.ResponseWriter) {", "method", "Handle"},
+		{"main.go", "func NewServer() *Server {", "function", "NewServer"},
+		{"main.go", "type Server struct {", "type", "Server"},
+		{"main.go", "\tsrv := NewServer()", "", ""},
+		{"app.py", "    async def run(self):", "function", "run"},
+		{"app.py", "class Handler(Base):", "class", "Handler"},
+		{"app.ts", "export default async function fetchData() {", "function", "fetchData"},
+		{"app.ts", "export type Props<T> = {", "type", "Props"},
+		{"app.ts", "export const handler = () => {", "variable", "handler"},
+		{"lib.rs", "pub(crate) async fn connect() {", "function", "connect"},
+		{"lib.rs", "pub trait Store {", "interface", "Store"},
+		{"User.kt", "data class User(", "class", "User"},
+		{"README.md", "func NewServer() *Server {", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path+"/"+tt.line, func(t *testing.T) {
+			t.Parallel()
+			kind, name := detectSymbol(tt.path, tt.line)
+			require.Equal(t, tt.kind, kind)
+			require.Equal(t, tt.name, name)
+		})
+	}
+}
+
+func TestCodeSearch(t *testing.T) {
+	t.Parallel()
+	tempDir := t.TempDir()
+
+	testFiles := map[string]string{
+		"server.go":           "package main\n\nfunc NewServer() *Server {\n\treturn &Server{}\n}\n",
+		"main.go":             "package main\n\nfunc main() {\n\tsrv := NewServer()\n\t_ = srv\n}\n",
+		"types.go":            "package main\n\ntype Server struct{}\n",
+		"api.pb.go":           "package main\n\nfunc NewServerClient() {}\n",
+		"gen.go":              "// Code generated by stringer. DO NOT EDIT.\n\npackage main\n\nfunc NewServerGen() {}\n",
+		"vendor/lib/lib.go":   "package lib\n\nfunc NewServer() {}\n",
+		"node_modules/x/a.js": "function NewServer() {}\n",
+		"web/app.js":          "NewServer();\n",
+		"web/app.min.js":      "NewServer();\n",
+		"build/out.go":        "package out\n\nvar _ = NewServer\n",
+		"internal/build/b.go": "package build\n\nfunc run() {\n\t_ = NewServer()\n}\n",
+		"ignored/ignored.go":  "package ignored\n\nvar _ = NewServer\n",
+		".gitignore":          "ignored/\n",
+	}
+	for path, content := range testFiles {
+		fullPath := filepath.Join(tempDir, path)
+		require.NoError(t, os.MkdirAll(filepath.Dir(fullPath), 0o755))
+		require.NoError(t, os.WriteFile(fullPath, []byte(content), 0o644))
+	}
+
+	for name, fn := range map[string]func(pattern, path, include string) ([]codeSearchMatch, error){
+		"regex": codeSearchWithRegex,
+		"rg": func(pattern, path, include string) ([]codeSearchMatch, error) {
+			return codeSearchWithRipgrep(t.Context(), pattern, path, include)
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if name == "rg" && getRg() == "" {
+				t.Skip("rg is not in $PATH")
+			}
+
+			relPaths := func(matches []codeSearchMatch) []string {
+				var paths []string
+				for _, m := range matches {
+					rel, err := filepath.Rel(tempDir, m.path)
+					require.NoError(t, err)
+					paths = append(paths, filepath.ToSlash(rel))
+				}
+				return paths
+			}
+
+			matches, err := fn("NewServer", tempDir, "")
+			require.NoError(t, err)
+			paths := relPaths(matches)
+			require.Contains(t, paths, "server.go")
+			require.NotContains(t, paths, "api.pb.go")
+			require.NotContains(t, paths, "vendor/lib/lib.go")
+			require.NotContains(t, paths, "node_modules/x/a.js")
+			require.NotContains(t, paths, "ignored/ignored.go")
+
+			// Build output is only skipped at the search root.
+			require.NotContains(t, paths, "build/out.go")
+			require.Contains(t, paths, "internal/build/b.go")
+
+			// Exclusions still apply to files matching the include glob.
+			matches, err = fn("NewServer", tempDir, "*.js")
+			require.NoError(t, err)
+			paths = relPaths(matches)
+			require.Equal(t, []string{"web/app.js"}, paths)
+		})
+	}
+
+	t.Run("definitions first", func(t *testing.T) {
+		t.Parallel()
+
+		results, truncated, err := codeSearch(t.Context(), "Server", tempDir, "", false, 100)
+		require.NoError(t, err)
+		require.False(t, truncated)
+		require.NotEmpty(t, results)
+
+		// Generated files are dropped even without a matching file name glob.
+		for _, r := range results {
+			require.NotEqual(t, "gen.go", r.Path)
+		}
+
+		// Only the symbol named exactly like the query gets the boost.
+		require.Equal(t, "types.go", results[0].Path)
+		require.Equal(t, "type", results[0].SymbolKind)
+		require.Equal(t, "Server", results[0].Symbol)
+		require.Equal(t, "server.go", results[1].Path)
+		require.Equal(t, "function", results[1].SymbolKind)
+		require.Equal(t, "NewServer", results[1].Symbol)
+		require.Empty(t, results[len(results)-1].SymbolKind)
+	})
+
+	t.Run("definitions only", func(t *testing.T) {
+		t.Parallel()
+
+		results, _, err := codeSearch(t.Context(), "NewServer", tempDir, "", true, 100)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		require.Equal(t, "server.go", results[0].Path)
+		require.Equal(t, 3, results[0].Line)
+	})
+
+	t.Run("exact names first", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "a.go"), []byte("package main\n\nfunc NewServerClient() {}\n"), 0o644))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "b.go"), []byte("package main\n\nfunc NewServer() {}\n"), 0o644))
+
+		results, _, err := codeSearch(t.Context(), "NewServer", dir, "", true, 100)
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		require.Equal(t, "NewServer", results[0].Symbol)
+		require.Equal(t, "NewServerClient", results[1].Symbol)
+	})
+
+	t.Run("truncates", func(t *testing.T) {
+		t.Parallel()
+
+		results, truncated, err := codeSearch(t.Context(), "package", tempDir, "", false, 2)
+		require.NoError(t, err)
+		require.True(t, truncated)
+		require.Len(t, results, 2)
+	})
+}
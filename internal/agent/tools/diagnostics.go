@@ -30,7 +30,7 @@ func NewDiagnosticsTool(lspClients *csync.Map[string, *lsp.Client]) fantasy.Agen
 		string(diagnosticsDescription),
 		func(ctx context.Context, params DiagnosticsParams, call fantasy.ToolCall) (fantasy.ToolResponse, error) {
 			if lspClients.Len() == 0 {
-				return fantasy.NewTextErrorResponse("no LSP clients available"), nil
+				return NewErrorResponse(ErrorKindUnavailable, "no LSP clients available"), nil
 			}
 			notifyLSPs(ctx, lspClients, params.FilePath)
 			output := getDiagnostics(params.FilePath, lspClients)
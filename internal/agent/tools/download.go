@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"charm.land/fantasy"
+	"github.com/charmbracelet/crush/internal/config"
 	"github.com/charmbracelet/crush/internal/filepathext"
 	"github.com/charmbracelet/crush/internal/permission"
 )
@@ -34,31 +35,28 @@ const DownloadToolName = "download"
 //go:embed download.md
 var downloadDescription []byte
 
-func NewDownloadTool(permissions permission.Service, workingDir string, client *http.Client) fantasy.AgentTool {
+func NewDownloadTool(permissions permission.Service, workingDir string, httpCfg config.ToolHTTP, client *http.Client) fantasy.AgentTool {
 	if client == nil {
-		client = &http.Client{
-			Timeout: 5 * time.Minute, // Default 5 minute timeout for downloads
-			Transport: &http.Transport{
-				MaxIdleConns:        100,
-				MaxIdleConnsPerHost: 10,
-				IdleConnTimeout:     90 * time.Second,
-			},
-		}
+		client = NewHTTPClient(5 * time.Minute) // Default 5 minute timeout for downloads
 	}
 	return fantasy.NewAgentTool(
 		DownloadToolName,
 		string(downloadDescription),
 		func(ctx context.Context, params DownloadParams, call fantasy.ToolCall) (fantasy.ToolResponse, error) {
+			if err := CheckNetworkPolicy(ctx, httpCfg, DownloadToolName); err != nil {
+				return NewErrorResponse(ErrorKindNetworkPolicy, err.Error()), nil
+			}
+
 			if params.URL == "" {
-				return fantasy.NewTextErrorResponse("URL parameter is required"), nil
+				return NewErrorResponse(ErrorKindInvalidArgs, "URL parameter is required"), nil
 			}
 
 			if params.FilePath == "" {
-				return fantasy.NewTextErrorResponse("file_path parameter is required"), nil
+				return NewErrorResponse(ErrorKindInvalidArgs, "file_path parameter is required"), nil
 			}
 
 			if !strings.HasPrefix(params.URL, "http://") && !strings.HasPrefix(params.URL, "https://") {
-				return fantasy.NewTextErrorResponse("URL must start with http:// or https://"), nil
+				return NewErrorResponse(ErrorKindInvalidArgs, "URL must start with http:// or https://"), nil
 			}
 
 			filePath := filepathext.SmartJoin(workingDir, params.FilePath)
@@ -102,22 +100,22 @@ func NewDownloadTool(permissions permission.Service, workingDir string, client *
 				return fantasy.ToolResponse{}, fmt.Errorf("failed to create request: %w", err)
 			}
 
-			req.Header.Set("User-Agent", "crush/1.0")
+			setRequestHeaders(req, httpCfg)
 
-			resp, err := client.Do(req)
+			resp, err := doWithRetry(client, req)
 			if err != nil {
-				return fantasy.ToolResponse{}, fmt.Errorf("failed to download from URL: %w", err)
+				return newRequestErrorResponse(ctx, err, "Failed to download from URL")
 			}
 			defer resp.Body.Close()
 
 			if resp.StatusCode != http.StatusOK {
-				return fantasy.NewTextErrorResponse(fmt.Sprintf("Request failed with status code: %d", resp.StatusCode)), nil
+				return newHTTPStatusErrorResponse(resp.StatusCode, fmt.Sprintf("Request failed with status code: %d", resp.StatusCode)), nil
 			}
 
 			// Check content length if available
 			maxSize := int64(100 * 1024 * 1024) // 100MB
 			if resp.ContentLength > maxSize {
-				return fantasy.NewTextErrorResponse(fmt.Sprintf("File too large: %d bytes (max %d bytes)", resp.ContentLength, maxSize)), nil
+				return NewErrorResponse(ErrorKindLimitExceeded, fmt.Sprintf("File too large: %d bytes (max %d bytes)", resp.ContentLength, maxSize)), nil
 			}
 
 			// Create parent directories if they don't exist
@@ -143,7 +141,7 @@ func NewDownloadTool(permissions permission.Service, workingDir string, client *
 			if bytesWritten == maxSize {
 				// Clean up the file since it might be incomplete
 				os.Remove(filePath)
-				return fantasy.NewTextErrorResponse(fmt.Sprintf("File too large: exceeded %d bytes limit", maxSize)), nil
+				return NewErrorResponse(ErrorKindLimitExceeded, fmt.Sprintf("File too large: exceeded %d bytes limit", maxSize)), nil
 			}
 
 			contentType := resp.Header.Get("Content-Type")
@@ -0,0 +1,109 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"charm.land/fantasy"
+)
+
+// ErrorKind categorizes a failed tool call so the agent, the UI and retry
+// logic can react to the kind of failure instead of matching on its text.
+type ErrorKind string
+
+const (
+	ErrorKindPermissionDenied ErrorKind = "permission_denied"
+	ErrorKindNetwork          ErrorKind = "network"
+	ErrorKindNotFound         ErrorKind = "not_found"
+	ErrorKindTimeout          ErrorKind = "timeout"
+	ErrorKindRateLimited      ErrorKind = "rate_limited"
+	ErrorKindInvalidArgs      ErrorKind = "invalid_args"
+	ErrorKindNetworkPolicy    ErrorKind = "network_policy"
+
+	// ErrorKindAccessDenied is a refusal by a remote server or its
+	// robots.txt, as opposed to ErrorKindPermissionDenied which means the
+	// user refused the tool call.
+	ErrorKindAccessDenied      ErrorKind = "access_denied"
+	ErrorKindConflict          ErrorKind = "conflict"
+	ErrorKindLimitExceeded     ErrorKind = "limit_exceeded"
+	ErrorKindUnsupportedFormat ErrorKind = "unsupported_format"
+	ErrorKindUnavailable       ErrorKind = "unavailable"
+	ErrorKindInternal          ErrorKind = "internal"
+)
+
+// ErrorMetadata is the metadata attached to error responses created with
+// NewErrorResponse.
+type ErrorMetadata struct {
+	ErrorKind ErrorKind `json:"error_kind"`
+}
+
+// NewErrorResponse returns an error response tagged with the given kind.
+// The kind is recorded in the metadata and, since the model only sees the
+// content, as a "[kind] " prefix of the content.
+func NewErrorResponse(kind ErrorKind, content string) fantasy.ToolResponse {
+	return fantasy.WithResponseMetadata(fantasy.NewTextErrorResponse(ErrorContent(kind, content)), ErrorMetadata{ErrorKind: kind})
+}
+
+// ErrorContent prefixes content with the given kind.
+func ErrorContent(kind ErrorKind, content string) string {
+	return "[" + string(kind) + "] " + content
+}
+
+// TrimErrorKind removes the prefix added by ErrorContent, for display
+// where the kind is shown separately.
+func TrimErrorKind(kind ErrorKind, content string) string {
+	if kind == "" {
+		return content
+	}
+	return strings.TrimPrefix(content, "["+string(kind)+"] ")
+}
+
+// ErrorKindFromMetadata returns the kind recorded in a tool result's
+// metadata, or an empty kind if the error was not categorized.
+func ErrorKindFromMetadata(metadata string) ErrorKind {
+	if metadata == "" {
+		return ""
+	}
+	var m ErrorMetadata
+	if err := json.Unmarshal([]byte(metadata), &m); err != nil {
+		return ""
+	}
+	return m.ErrorKind
+}
+
+// newHTTPStatusErrorResponse returns an error response for an unexpected
+// HTTP status code.
+func newHTTPStatusErrorResponse(statusCode int, content string) fantasy.ToolResponse {
+	kind := ErrorKindNetwork
+	switch statusCode {
+	case http.StatusNotFound, http.StatusGone:
+		kind = ErrorKindNotFound
+	case http.StatusTooManyRequests:
+		kind = ErrorKindRateLimited
+	case http.StatusRequestTimeout, http.StatusGatewayTimeout:
+		kind = ErrorKindTimeout
+	case http.StatusUnauthorized, http.StatusForbidden:
+		kind = ErrorKindAccessDenied
+	}
+	return NewErrorResponse(kind, content)
+}
+
+// newRequestErrorResponse returns an error response for a request that
+// failed before a response was received. Cancellation by the user is
+// returned as an error so the agent stops, as before.
+func newRequestErrorResponse(ctx context.Context, err error, action string) (fantasy.ToolResponse, error) {
+	if errors.Is(ctx.Err(), context.Canceled) {
+		return fantasy.ToolResponse{}, fmt.Errorf("%s: %w", action, err)
+	}
+	kind := ErrorKindNetwork
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		kind = ErrorKindTimeout
+	}
+	return NewErrorResponse(kind, fmt.Sprintf("%s: %s", action, err)), nil
+}
@@ -0,0 +1,59 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrorKindFromMetadata(t *testing.T) {
+	t.Parallel()
+
+	resp := NewErrorResponse(ErrorKindNotFound, "file not found: main.go")
+	require.True(t, resp.IsError)
+	require.Equal(t, "[not_found] file not found: main.go", resp.Content)
+	require.Equal(t, ErrorKindNotFound, ErrorKindFromMetadata(resp.Metadata))
+	require.Equal(t, "file not found: main.go", TrimErrorKind(ErrorKindNotFound, resp.Content))
+
+	require.Empty(t, ErrorKindFromMetadata(""))
+	require.Empty(t, ErrorKindFromMetadata("not json"))
+	require.Empty(t, ErrorKindFromMetadata(`{"file_path":"main.go"}`))
+}
+
+func TestHTTPStatusErrorResponse(t *testing.T) {
+	t.Parallel()
+
+	tests := map[int]ErrorKind{
+		http.StatusNotFound:            ErrorKindNotFound,
+		http.StatusTooManyRequests:     ErrorKindRateLimited,
+		http.StatusGatewayTimeout:      ErrorKindTimeout,
+		http.StatusUnauthorized:        ErrorKindAccessDenied,
+		http.StatusForbidden:           ErrorKindAccessDenied,
+		http.StatusInternalServerError: ErrorKindNetwork,
+	}
+	for status, kind := range tests {
+		resp := newHTTPStatusErrorResponse(status, "request failed")
+		require.Equal(t, kind, ErrorKindFromMetadata(resp.Metadata), status)
+	}
+}
+
+func TestRequestErrorResponse(t *testing.T) {
+	t.Parallel()
+
+	resp, err := newRequestErrorResponse(t.Context(), context.DeadlineExceeded, "Failed to fetch URL")
+	require.NoError(t, err)
+	require.Equal(t, ErrorKindTimeout, ErrorKindFromMetadata(resp.Metadata))
+
+	resp, err = newRequestErrorResponse(t.Context(), errors.New("connection refused"), "Failed to fetch URL")
+	require.NoError(t, err)
+	require.Equal(t, ErrorKindNetwork, ErrorKindFromMetadata(resp.Metadata))
+	require.Contains(t, resp.Content, "connection refused")
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	_, err = newRequestErrorResponse(ctx, context.Canceled, "Failed to fetch URL")
+	require.ErrorIs(t, err, context.Canceled)
+}
@@ -3,6 +3,7 @@ package tools
 import (
 	"context"
 	_ "embed"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -13,6 +14,7 @@ import (
 	"charm.land/fantasy"
 	md "github.com/JohannesKaufmann/html-to-markdown"
 	"github.com/PuerkitoBio/goquery"
+	"github.com/charmbracelet/crush/internal/config"
 	"github.com/charmbracelet/crush/internal/permission"
 )
 
@@ -21,33 +23,30 @@ const FetchToolName = "fetch"
 //go:embed fetch.md
 var fetchDescription []byte
 
-func NewFetchTool(permissions permission.Service, workingDir string, client *http.Client) fantasy.AgentTool {
+func NewFetchTool(permissions permission.Service, workingDir string, httpCfg config.ToolHTTP, client *http.Client) fantasy.AgentTool {
 	if client == nil {
-		client = &http.Client{
-			Timeout: 30 * time.Second,
-			Transport: &http.Transport{
-				MaxIdleConns:        100,
-				MaxIdleConnsPerHost: 10,
-				IdleConnTimeout:     90 * time.Second,
-			},
-		}
+		client = NewHTTPClient(30 * time.Second)
 	}
 
 	return fantasy.NewAgentTool(
 		FetchToolName,
 		string(fetchDescription),
 		func(ctx context.Context, params FetchParams, call fantasy.ToolCall) (fantasy.ToolResponse, error) {
+			if err := CheckNetworkPolicy(ctx, httpCfg, FetchToolName); err != nil {
+				return NewErrorResponse(ErrorKindNetworkPolicy, err.Error()), nil
+			}
+
 			if params.URL == "" {
-				return fantasy.NewTextErrorResponse("URL parameter is required"), nil
+				return NewErrorResponse(ErrorKindInvalidArgs, "URL parameter is required"), nil
 			}
 
 			format := strings.ToLower(params.Format)
 			if format != "text" && format != "markdown" && format != "html" {
-				return fantasy.NewTextErrorResponse("Format must be one of: text, markdown, html"), nil
+				return NewErrorResponse(ErrorKindInvalidArgs, "Format must be one of: text, markdown, html"), nil
 			}
 
 			if !strings.HasPrefix(params.URL, "http://") && !strings.HasPrefix(params.URL, "https://") {
-				return fantasy.NewTextErrorResponse("URL must start with http:// or https://"), nil
+				return NewErrorResponse(ErrorKindInvalidArgs, "URL must start with http:// or https://"), nil
 			}
 
 			sessionID := GetSessionFromContext(ctx)
@@ -71,6 +70,12 @@ func NewFetchTool(permissions permission.Service, workingDir string, client *htt
 				return fantasy.ToolResponse{}, permission.ErrorPermissionDenied
 			}
 
+			// Wait for robots.txt and the host's crawl-delay before the
+			// request timeout starts running.
+			if err := checkRobots(ctx, client, httpCfg, params.URL); err != nil {
+				return newRobotsErrorResponse(ctx, err)
+			}
+
 			// Handle timeout with context
 			requestCtx := ctx
 			if params.Timeout > 0 {
@@ -88,29 +93,32 @@ func NewFetchTool(permissions permission.Service, workingDir string, client *htt
 				return fantasy.ToolResponse{}, fmt.Errorf("failed to create request: %w", err)
 			}
 
-			req.Header.Set("User-Agent", "crush/1.0")
+			setRequestHeaders(req, httpCfg)
 
-			resp, err := client.Do(req)
+			resp, err := doWithRetry(robotsClient(client, httpCfg), req)
+			if errors.Is(err, ErrDisallowedByRobots) || errors.Is(err, ErrCrawlDelay) {
+				return newRobotsErrorResponse(ctx, err)
+			}
 			if err != nil {
-				return fantasy.ToolResponse{}, fmt.Errorf("failed to fetch URL: %w", err)
+				return newRequestErrorResponse(ctx, err, "Failed to fetch URL")
 			}
 			defer resp.Body.Close()
 
 			if resp.StatusCode != http.StatusOK {
-				return fantasy.NewTextErrorResponse(fmt.Sprintf("Request failed with status code: %d", resp.StatusCode)), nil
+				return newHTTPStatusErrorResponse(resp.StatusCode, fmt.Sprintf("Request failed with status code: %d", resp.StatusCode)), nil
 			}
 
 			maxSize := int64(5 * 1024 * 1024) // 5MB
 			body, err := io.ReadAll(io.LimitReader(resp.Body, maxSize))
 			if err != nil {
-				return fantasy.NewTextErrorResponse("Failed to read response body: " + err.Error()), nil
+				return NewErrorResponse(ErrorKindNetwork, "Failed to read response body: "+err.Error()), nil
 			}
 
 			content := string(body)
 
 			isValidUt8 := utf8.ValidString(content)
 			if !isValidUt8 {
-				return fantasy.NewTextErrorResponse("Response content is not valid UTF-8"), nil
+				return NewErrorResponse(ErrorKindUnsupportedFormat, "Response content is not valid UTF-8"), nil
 			}
 			contentType := resp.Header.Get("Content-Type")
 
@@ -119,7 +127,7 @@ func NewFetchTool(permissions permission.Service, workingDir string, client *htt
 				if strings.Contains(contentType, "text/html") {
 					text, err := extractTextFromHTML(content)
 					if err != nil {
-						return fantasy.NewTextErrorResponse("Failed to extract text from HTML: " + err.Error()), nil
+						return NewErrorResponse(ErrorKindUnsupportedFormat, "Failed to extract text from HTML: "+err.Error()), nil
 					}
 					content = text
 				}
@@ -128,7 +136,7 @@ func NewFetchTool(permissions permission.Service, workingDir string, client *htt
 				if strings.Contains(contentType, "text/html") {
 					markdown, err := convertHTMLToMarkdown(content)
 					if err != nil {
-						return fantasy.NewTextErrorResponse("Failed to convert HTML to Markdown: " + err.Error()), nil
+						return NewErrorResponse(ErrorKindUnsupportedFormat, "Failed to convert HTML to Markdown: "+err.Error()), nil
 					}
 					content = markdown
 				}
@@ -140,14 +148,14 @@ func NewFetchTool(permissions permission.Service, workingDir string, client *htt
 				if strings.Contains(contentType, "text/html") {
 					doc, err := goquery.NewDocumentFromReader(strings.NewReader(content))
 					if err != nil {
-						return fantasy.NewTextErrorResponse("Failed to parse HTML: " + err.Error()), nil
+						return NewErrorResponse(ErrorKindUnsupportedFormat, "Failed to parse HTML: "+err.Error()), nil
 					}
 					body, err := doc.Find("body").Html()
 					if err != nil {
-						return fantasy.NewTextErrorResponse("Failed to extract body from HTML: " + err.Error()), nil
+						return NewErrorResponse(ErrorKindUnsupportedFormat, "Failed to extract body from HTML: "+err.Error()), nil
 					}
 					if body == "" {
-						return fantasy.NewTextErrorResponse("No body content found in HTML"), nil
+						return NewErrorResponse(ErrorKindUnsupportedFormat, "No body content found in HTML"), nil
 					}
 					content = "<html>\n<body>\n" + body + "\n</body>\n</html>"
 				}
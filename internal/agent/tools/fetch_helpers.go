@@ -12,18 +12,23 @@ import (
 	"unicode/utf8"
 
 	md "github.com/JohannesKaufmann/html-to-markdown"
+	"github.com/charmbracelet/crush/internal/config"
 )
 
 // FetchURLAndConvert fetches a URL and converts HTML content to markdown.
-func FetchURLAndConvert(ctx context.Context, client *http.Client, url string) (string, error) {
+func FetchURLAndConvert(ctx context.Context, client *http.Client, httpCfg config.ToolHTTP, url string) (string, error) {
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return "", fmt.Errorf("failed to create request: %w", err)
 	}
 
-	req.Header.Set("User-Agent", "crush/1.0")
+	setRequestHeaders(req, httpCfg)
 
-	resp, err := client.Do(req)
+	if err := checkRobots(ctx, client, httpCfg, url); err != nil {
+		return "", err
+	}
+
+	resp, err := doWithRetry(robotsClient(client, httpCfg), req)
 	if err != nil {
 		return "", fmt.Errorf("failed to fetch URL: %w", err)
 	}
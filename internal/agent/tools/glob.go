@@ -36,7 +36,7 @@ func NewGlobTool(workingDir string) fantasy.AgentTool {
 		string(globDescription),
 		func(ctx context.Context, params GlobParams, call fantasy.ToolCall) (fantasy.ToolResponse, error) {
 			if params.Pattern == "" {
-				return fantasy.NewTextErrorResponse("pattern is required"), nil
+				return NewErrorResponse(ErrorKindInvalidArgs, "pattern is required"), nil
 			}
 
 			searchPath := params.Path
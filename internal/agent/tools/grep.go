@@ -118,7 +118,7 @@ func NewGrepTool(workingDir string) fantasy.AgentTool {
 		string(grepDescription),
 		func(ctx context.Context, params GrepParams, call fantasy.ToolCall) (fantasy.ToolResponse, error) {
 			if params.Pattern == "" {
-				return fantasy.NewTextErrorResponse("pattern is required"), nil
+				return NewErrorResponse(ErrorKindInvalidArgs, "pattern is required"), nil
 			}
 
 			// If literal_text is true, escape the pattern
@@ -134,7 +134,7 @@ func NewGrepTool(workingDir string) fantasy.AgentTool {
 
 			matches, truncated, err := searchFiles(ctx, searchPattern, searchPath, params.Include, 100)
 			if err != nil {
-				return fantasy.NewTextErrorResponse(fmt.Sprintf("error searching files: %v", err)), nil
+				return NewErrorResponse(ErrorKindInternal, fmt.Sprintf("error searching files: %v", err)), nil
 			}
 
 			var output strings.Builder
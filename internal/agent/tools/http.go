@@ -0,0 +1,73 @@
+package tools
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/crush/internal/config"
+)
+
+// defaultUserAgent is sent by the network tools unless another one is
+// configured.
+const defaultUserAgent = "crush/1.0"
+
+// setRequestHeaders sets the configured User-Agent and additional headers
+// on a request made by a network tool.
+func setRequestHeaders(req *http.Request, cfg config.ToolHTTP) {
+	req.Header.Set("User-Agent", cmp.Or(cfg.UserAgent, defaultUserAgent))
+	for key, value := range cfg.Headers {
+		req.Header.Set(key, value)
+	}
+}
+
+// sharedTransport is used by all the network tools so connections are pooled
+// and reused across tools and calls. It starts from the default transport to
+// keep proxy support, dial and TLS timeouts and HTTP/2.
+var sharedTransport = sync.OnceValue(func() *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConns = 100
+	transport.MaxIdleConnsPerHost = 10
+	transport.IdleConnTimeout = 90 * time.Second
+	transport.ForceAttemptHTTP2 = true
+	return transport
+})
+
+// NewHTTPClient returns a client for a network tool with the given overall
+// request timeout, backed by the shared transport.
+func NewHTTPClient(timeout time.Duration) *http.Client {
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: sharedTransport(),
+	}
+}
+
+// ErrNetworkPolicy is returned by CheckNetworkPolicy when a tool may not
+// reach the network.
+var ErrNetworkPolicy = errors.New("network access blocked by policy")
+
+// CheckNetworkPolicy returns an error if the configured network policy for
+// the kind of session in ctx does not let the named tool reach the network.
+func CheckNetworkPolicy(ctx context.Context, cfg config.ToolHTTP, toolName string) error {
+	if cfg.Offline {
+		return fmt.Errorf("%w: network tools are disabled (offline mode)", ErrNetworkPolicy)
+	}
+	kind := GetSessionKindFromContext(ctx)
+	policy := cfg.Interactive
+	if kind == SessionNonInteractive {
+		policy = cfg.NonInteractive
+	}
+	sessions := strings.ReplaceAll(string(kind), "_", "-")
+	if policy.Offline {
+		return fmt.Errorf("%w: network tools are disabled in %s sessions", ErrNetworkPolicy, sessions)
+	}
+	if !policy.Allows(toolName) {
+		return fmt.Errorf("%w: the %s tool may not be used in %s sessions", ErrNetworkPolicy, toolName, sessions)
+	}
+	return nil
+}
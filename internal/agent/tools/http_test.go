@@ -0,0 +1,67 @@
+package tools
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/crush/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetRequestHeaders(t *testing.T) {
+	t.Parallel()
+
+	t.Run("default user agent", func(t *testing.T) {
+		t.Parallel()
+
+		req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, "https://example.com", nil)
+		require.NoError(t, err)
+		setRequestHeaders(req, config.ToolHTTP{})
+		require.Equal(t, defaultUserAgent, req.Header.Get("User-Agent"))
+	})
+
+	t.Run("configured user agent and headers", func(t *testing.T) {
+		t.Parallel()
+
+		req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, "https://example.com", nil)
+		require.NoError(t, err)
+		setRequestHeaders(req, config.ToolHTTP{
+			UserAgent: "Mozilla/5.0",
+			Headers:   map[string]string{"Accept-Language": "en-US"},
+		})
+		require.Equal(t, "Mozilla/5.0", req.Header.Get("User-Agent"))
+		require.Equal(t, "en-US", req.Header.Get("Accept-Language"))
+	})
+}
+
+func TestNewHTTPClient(t *testing.T) {
+	t.Parallel()
+
+	a := NewHTTPClient(30 * time.Second)
+	b := NewHTTPClient(5 * time.Minute)
+	require.Equal(t, 30*time.Second, a.Timeout)
+	require.Equal(t, 5*time.Minute, b.Timeout)
+	require.Same(t, a.Transport, b.Transport)
+}
+
+func TestCheckNetworkPolicy(t *testing.T) {
+	t.Parallel()
+
+	nonInteractive := context.WithValue(t.Context(), SessionKindContextKey, SessionNonInteractive)
+	cfg := config.ToolHTTP{
+		Interactive:    config.NetworkPolicy{AllowedTools: []string{FetchToolName}},
+		NonInteractive: config.NetworkPolicy{Offline: true},
+	}
+
+	require.NoError(t, CheckNetworkPolicy(t.Context(), config.ToolHTTP{}, DownloadToolName))
+	require.NoError(t, CheckNetworkPolicy(t.Context(), cfg, FetchToolName))
+	require.ErrorIs(t, CheckNetworkPolicy(t.Context(), cfg, DownloadToolName), ErrNetworkPolicy)
+	require.ErrorIs(t, CheckNetworkPolicy(nonInteractive, cfg, FetchToolName), ErrNetworkPolicy)
+
+	cfg.Offline = true
+	err := CheckNetworkPolicy(t.Context(), cfg, FetchToolName)
+	require.ErrorIs(t, err, ErrNetworkPolicy)
+	require.ErrorContains(t, err, "offline")
+}
@@ -32,14 +32,14 @@ func NewJobKillTool() fantasy.AgentTool {
 		string(jobKillDescription),
 		func(ctx context.Context, params JobKillParams, call fantasy.ToolCall) (fantasy.ToolResponse, error) {
 			if params.ShellID == "" {
-				return fantasy.NewTextErrorResponse("missing shell_id"), nil
+				return NewErrorResponse(ErrorKindInvalidArgs, "missing shell_id"), nil
 			}
 
 			bgManager := shell.GetBackgroundShellManager()
 
 			bgShell, ok := bgManager.Get(params.ShellID)
 			if !ok {
-				return fantasy.NewTextErrorResponse(fmt.Sprintf("background shell not found: %s", params.ShellID)), nil
+				return NewErrorResponse(ErrorKindNotFound, fmt.Sprintf("background shell not found: %s", params.ShellID)), nil
 			}
 
 			metadata := JobKillResponseMetadata{
@@ -50,7 +50,7 @@ func NewJobKillTool() fantasy.AgentTool {
 
 			err := bgManager.Kill(params.ShellID)
 			if err != nil {
-				return fantasy.NewTextErrorResponse(err.Error()), nil
+				return NewErrorResponse(ErrorKindNotFound, err.Error()), nil
 			}
 
 			result := fmt.Sprintf("Background shell %s terminated successfully", params.ShellID)
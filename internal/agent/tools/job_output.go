@@ -35,13 +35,13 @@ func NewJobOutputTool() fantasy.AgentTool {
 		string(jobOutputDescription),
 		func(ctx context.Context, params JobOutputParams, call fantasy.ToolCall) (fantasy.ToolResponse, error) {
 			if params.ShellID == "" {
-				return fantasy.NewTextErrorResponse("missing shell_id"), nil
+				return NewErrorResponse(ErrorKindInvalidArgs, "missing shell_id"), nil
 			}
 
 			bgManager := shell.GetBackgroundShellManager()
 			bgShell, ok := bgManager.Get(params.ShellID)
 			if !ok {
-				return fantasy.NewTextErrorResponse(fmt.Sprintf("background shell not found: %s", params.ShellID)), nil
+				return NewErrorResponse(ErrorKindNotFound, fmt.Sprintf("background shell not found: %s", params.ShellID)), nil
 			}
 
 			stdout, stderr, done, err := bgShell.GetOutput()
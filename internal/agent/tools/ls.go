@@ -55,7 +55,7 @@ func NewLsTool(permissions permission.Service, workingDir string, lsConfig confi
 		func(ctx context.Context, params LSParams, call fantasy.ToolCall) (fantasy.ToolResponse, error) {
 			searchPath, err := fsext.Expand(cmp.Or(params.Path, workingDir))
 			if err != nil {
-				return fantasy.NewTextErrorResponse(fmt.Sprintf("error expanding path: %v", err)), nil
+				return NewErrorResponse(ErrorKindInvalidArgs, fmt.Sprintf("error expanding path: %v", err)), nil
 			}
 
 			searchPath = filepathext.SmartJoin(workingDir, searchPath)
@@ -63,12 +63,12 @@ func NewLsTool(permissions permission.Service, workingDir string, lsConfig confi
 			// Check if directory is outside working directory and request permission if needed
 			absWorkingDir, err := filepath.Abs(workingDir)
 			if err != nil {
-				return fantasy.NewTextErrorResponse(fmt.Sprintf("error resolving working directory: %v", err)), nil
+				return NewErrorResponse(ErrorKindInternal, fmt.Sprintf("error resolving working directory: %v", err)), nil
 			}
 
 			absSearchPath, err := filepath.Abs(searchPath)
 			if err != nil {
-				return fantasy.NewTextErrorResponse(fmt.Sprintf("error resolving search path: %v", err)), nil
+				return NewErrorResponse(ErrorKindInvalidArgs, fmt.Sprintf("error resolving search path: %v", err)), nil
 			}
 
 			relPath, err := filepath.Rel(absWorkingDir, absSearchPath)
@@ -98,7 +98,11 @@ func NewLsTool(permissions permission.Service, workingDir string, lsConfig confi
 
 			output, metadata, err := ListDirectoryTree(searchPath, params, lsConfig)
 			if err != nil {
-				return fantasy.NewTextErrorResponse(err.Error()), err
+				kind := ErrorKindInternal
+				if _, statErr := os.Stat(searchPath); os.IsNotExist(statErr) {
+					kind = ErrorKindNotFound
+				}
+				return NewErrorResponse(kind, err.Error()), err
 			}
 
 			return fantasy.WithResponseMetadata(
@@ -106,7 +106,7 @@ func (m *Tool) Run(ctx context.Context, params fantasy.ToolCall) (fantasy.ToolRe
 
 	content, err := mcp.RunTool(ctx, m.mcpName, m.tool.Name, params.Input)
 	if err != nil {
-		return fantasy.NewTextErrorResponse(err.Error()), nil
+		return NewErrorResponse(ErrorKindInternal, err.Error()), nil
 	}
 	return fantasy.NewTextResponse(content), nil
 }
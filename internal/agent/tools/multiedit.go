@@ -50,6 +50,7 @@ type MultiEditResponseMetadata struct {
 	NewContent   string       `json:"new_content,omitempty"`
 	EditsApplied int          `json:"edits_applied"`
 	EditsFailed  []FailedEdit `json:"edits_failed,omitempty"`
+	ErrorKind    ErrorKind    `json:"error_kind,omitempty"`
 }
 
 const MultiEditToolName = "multiedit"
@@ -63,18 +64,18 @@ func NewMultiEditTool(lspClients *csync.Map[string, *lsp.Client], permissions pe
 		string(multieditDescription),
 		func(ctx context.Context, params MultiEditParams, call fantasy.ToolCall) (fantasy.ToolResponse, error) {
 			if params.FilePath == "" {
-				return fantasy.NewTextErrorResponse("file_path is required"), nil
+				return NewErrorResponse(ErrorKindInvalidArgs, "file_path is required"), nil
 			}
 
 			if len(params.Edits) == 0 {
-				return fantasy.NewTextErrorResponse("at least one edit operation is required"), nil
+				return NewErrorResponse(ErrorKindInvalidArgs, "at least one edit operation is required"), nil
 			}
 
 			params.FilePath = filepathext.SmartJoin(workingDir, params.FilePath)
 
 			// Validate all edits before applying any
 			if err := validateEdits(params.Edits); err != nil {
-				return fantasy.NewTextErrorResponse(err.Error()), nil
+				return NewErrorResponse(ErrorKindInvalidArgs, err.Error()), nil
 			}
 
 			var response fantasy.ToolResponse
@@ -121,12 +122,12 @@ func processMultiEditWithCreation(edit editContext, params MultiEditParams, call
 	// First edit creates the file
 	firstEdit := params.Edits[0]
 	if firstEdit.OldString != "" {
-		return fantasy.NewTextErrorResponse("first edit must have empty old_string for file creation"), nil
+		return NewErrorResponse(ErrorKindInvalidArgs, "first edit must have empty old_string for file creation"), nil
 	}
 
 	// Check if file already exists
 	if _, err := os.Stat(params.FilePath); err == nil {
-		return fantasy.NewTextErrorResponse(fmt.Sprintf("file already exists: %s", params.FilePath)), nil
+		return NewErrorResponse(ErrorKindConflict, fmt.Sprintf("file already exists: %s", params.FilePath)), nil
 	} else if !os.IsNotExist(err) {
 		return fantasy.ToolResponse{}, fmt.Errorf("failed to access file: %w", err)
 	}
@@ -228,25 +229,25 @@ func processMultiEditExistingFile(edit editContext, params MultiEditParams, call
 	fileInfo, err := os.Stat(params.FilePath)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return fantasy.NewTextErrorResponse(fmt.Sprintf("file not found: %s", params.FilePath)), nil
+			return NewErrorResponse(ErrorKindNotFound, fmt.Sprintf("file not found: %s", params.FilePath)), nil
 		}
 		return fantasy.ToolResponse{}, fmt.Errorf("failed to access file: %w", err)
 	}
 
 	if fileInfo.IsDir() {
-		return fantasy.NewTextErrorResponse(fmt.Sprintf("path is a directory, not a file: %s", params.FilePath)), nil
+		return NewErrorResponse(ErrorKindInvalidArgs, fmt.Sprintf("path is a directory, not a file: %s", params.FilePath)), nil
 	}
 
 	// Check if file was read before editing
 	if getLastReadTime(params.FilePath).IsZero() {
-		return fantasy.NewTextErrorResponse("you must read the file before editing it. Use the View tool first"), nil
+		return NewErrorResponse(ErrorKindConflict, "you must read the file before editing it. Use the View tool first"), nil
 	}
 
 	// Check if file was modified since last read
 	modTime := fileInfo.ModTime()
 	lastRead := getLastReadTime(params.FilePath)
 	if modTime.After(lastRead) {
-		return fantasy.NewTextErrorResponse(
+		return NewErrorResponse(ErrorKindConflict,
 			fmt.Sprintf("file %s has been modified since it was last read (mod time: %s, last read: %s)",
 				params.FilePath, modTime.Format(time.RFC3339), lastRead.Format(time.RFC3339),
 			)), nil
@@ -281,14 +282,15 @@ func processMultiEditExistingFile(edit editContext, params MultiEditParams, call
 		// If we have failed edits, report them
 		if len(failedEdits) > 0 {
 			return fantasy.WithResponseMetadata(
-				fantasy.NewTextErrorResponse(fmt.Sprintf("no changes made - all %d edit(s) failed", len(failedEdits))),
+				NewErrorResponse(ErrorKindInvalidArgs, fmt.Sprintf("no changes made - all %d edit(s) failed", len(failedEdits))),
 				MultiEditResponseMetadata{
 					EditsApplied: 0,
 					EditsFailed:  failedEdits,
+					ErrorKind:    ErrorKindInvalidArgs,
 				},
 			), nil
 		}
-		return fantasy.NewTextErrorResponse("no changes made - all edits resulted in identical content"), nil
+		return NewErrorResponse(ErrorKindInvalidArgs, "no changes made - all edits resulted in identical content"), nil
 	}
 
 	// Get session and message IDs
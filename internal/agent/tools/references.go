@@ -40,18 +40,18 @@ func NewReferencesTool(lspClients *csync.Map[string, *lsp.Client]) fantasy.Agent
 		string(referencesDescription),
 		func(ctx context.Context, params ReferencesParams, call fantasy.ToolCall) (fantasy.ToolResponse, error) {
 			if params.Symbol == "" {
-				return fantasy.NewTextErrorResponse("symbol is required"), nil
+				return NewErrorResponse(ErrorKindInvalidArgs, "symbol is required"), nil
 			}
 
 			if lspClients.Len() == 0 {
-				return fantasy.NewTextErrorResponse("no LSP clients available"), nil
+				return NewErrorResponse(ErrorKindUnavailable, "no LSP clients available"), nil
 			}
 
 			workingDir := cmp.Or(params.Path, ".")
 
 			matches, _, err := searchFiles(ctx, regexp.QuoteMeta(params.Symbol), workingDir, "", 100)
 			if err != nil {
-				return fantasy.NewTextErrorResponse(fmt.Sprintf("failed to search for symbol: %s", err)), nil
+				return NewErrorResponse(ErrorKindInternal, fmt.Sprintf("failed to search for symbol: %s", err)), nil
 			}
 
 			if len(matches) == 0 {
@@ -81,7 +81,7 @@ func NewReferencesTool(lspClients *csync.Map[string, *lsp.Client]) fantasy.Agent
 			}
 
 			if allErrs != nil {
-				return fantasy.NewTextErrorResponse(allErrs.Error()), nil
+				return NewErrorResponse(ErrorKindInternal, allErrs.Error()), nil
 			}
 			return fantasy.NewTextResponse(fmt.Sprintf("No references found for symbol '%s'", params.Symbol)), nil
 		})
@@ -0,0 +1,152 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// retryPolicy controls how transient HTTP failures are retried.
+type retryPolicy struct {
+	// maxAttempts is the total number of attempts, including the first one.
+	maxAttempts int
+	// baseDelay is the backoff before the first retry; it doubles on every
+	// following attempt.
+	baseDelay time.Duration
+	// maxDelay caps both the computed backoff and any Retry-After the server
+	// asks for. Responses asking for a longer wait are returned as-is.
+	maxDelay time.Duration
+}
+
+// defaultRetryPolicy is used by the tools that reach out to the network.
+var defaultRetryPolicy = retryPolicy{
+	maxAttempts: 3,
+	baseDelay:   500 * time.Millisecond,
+	maxDelay:    10 * time.Second,
+}
+
+// doWithRetry sends req with the default retry policy.
+func doWithRetry(client *http.Client, req *http.Request) (*http.Response, error) {
+	return defaultRetryPolicy.do(client, req)
+}
+
+// do sends req, retrying transient network errors, 429 and 5xx responses with
+// exponential backoff and full jitter. A Retry-After header on the response
+// takes precedence over the computed backoff. When all attempts are used the
+// last response or error is returned unchanged so callers can report it.
+func (p retryPolicy) do(client *http.Client, req *http.Request) (*http.Response, error) {
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		// The body can't be replayed, so there is nothing to retry with.
+		return client.Do(req)
+	}
+
+	ctx := req.Context()
+	for attempt := 1; ; attempt++ {
+		attemptReq := req
+		if attempt > 1 {
+			var err error
+			attemptReq, err = rewindRequest(req)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		resp, err := client.Do(attemptReq)
+		if attempt >= p.maxAttempts || !shouldRetry(ctx, resp, err) {
+			return resp, err
+		}
+
+		delay := p.backoff(attempt)
+		if resp != nil {
+			if wait, ok := retryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+				if wait > p.maxDelay {
+					return resp, nil
+				}
+				delay = wait
+			}
+			// Drain so the connection can be reused.
+			_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+			resp.Body.Close()
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
+// backoff returns a random delay in [0, min(maxDelay, baseDelay*2^(attempt-1))].
+func (p retryPolicy) backoff(attempt int) time.Duration {
+	ceiling := min(p.baseDelay<<(attempt-1), p.maxDelay)
+	if ceiling <= 0 {
+		return 0
+	}
+	return rand.N(ceiling + 1)
+}
+
+func shouldRetry(ctx context.Context, resp *http.Response, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if err != nil {
+		return isTransientError(err)
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// isTransientError reports whether a transport error is worth retrying: the
+// connection was refused, reset or cut short. Anything else, like a host
+// that doesn't resolve, a bad certificate or a client timeout, would fail
+// the same way again.
+func isTransientError(err error) bool {
+	return errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.ECONNABORTED) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, io.EOF)
+}
+
+// retryAfter parses a Retry-After header, which is either a number of seconds
+// or an HTTP date.
+func retryAfter(header string, now time.Time) (time.Duration, bool) {
+	if header == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(header); err == nil {
+		return max(time.Duration(seconds)*time.Second, 0), true
+	}
+	if date, err := http.ParseTime(header); err == nil {
+		return max(date.Sub(now), 0), true
+	}
+	return 0, false
+}
+
+// rewindRequest returns a copy of req with a fresh body, so it can be sent
+// again.
+func rewindRequest(req *http.Request) (*http.Request, error) {
+	clone := req.Clone(req.Context())
+	if req.Body == nil || req.Body == http.NoBody {
+		return clone, nil
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	clone.Body = body
+	return clone, nil
+}
@@ -0,0 +1,258 @@
+package tools
+
+import (
+	"crypto/x509"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+var testRetryPolicy = retryPolicy{
+	maxAttempts: 3,
+	baseDelay:   time.Millisecond,
+	maxDelay:    10 * time.Millisecond,
+}
+
+func TestRetryPolicy(t *testing.T) {
+	t.Parallel()
+
+	t.Run("retries transient failures", func(t *testing.T) {
+		t.Parallel()
+
+		var calls atomic.Int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// Every attempt must carry the full request body.
+			if body, _ := io.ReadAll(r.Body); string(body) != "payload" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			switch calls.Add(1) {
+			case 1:
+				w.WriteHeader(http.StatusTooManyRequests)
+			case 2:
+				w.WriteHeader(http.StatusBadGateway)
+			default:
+				_, _ = w.Write([]byte("ok"))
+			}
+		}))
+		t.Cleanup(srv.Close)
+
+		req, err := http.NewRequestWithContext(t.Context(), http.MethodPost, srv.URL, strings.NewReader("payload"))
+		require.NoError(t, err)
+
+		resp, err := testRetryPolicy.do(srv.Client(), req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Equal(t, int32(3), calls.Load())
+	})
+
+	t.Run("gives up after max attempts", func(t *testing.T) {
+		t.Parallel()
+
+		var calls atomic.Int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		t.Cleanup(srv.Close)
+
+		req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, srv.URL, nil)
+		require.NoError(t, err)
+
+		resp, err := testRetryPolicy.do(srv.Client(), req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+		require.Equal(t, int32(3), calls.Load())
+	})
+
+	t.Run("does not retry client errors", func(t *testing.T) {
+		t.Parallel()
+
+		var calls atomic.Int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		t.Cleanup(srv.Close)
+
+		req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, srv.URL, nil)
+		require.NoError(t, err)
+
+		resp, err := testRetryPolicy.do(srv.Client(), req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusNotFound, resp.StatusCode)
+		require.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("returns when Retry-After exceeds max delay", func(t *testing.T) {
+		t.Parallel()
+
+		var calls atomic.Int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			w.Header().Set("Retry-After", "3600")
+			w.WriteHeader(http.StatusTooManyRequests)
+		}))
+		t.Cleanup(srv.Close)
+
+		req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, srv.URL, nil)
+		require.NoError(t, err)
+
+		resp, err := testRetryPolicy.do(srv.Client(), req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+		require.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("does not retry client timeouts", func(t *testing.T) {
+		t.Parallel()
+
+		var calls atomic.Int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			select {
+			case <-r.Context().Done():
+			case <-time.After(time.Second):
+			}
+		}))
+		t.Cleanup(srv.Close)
+
+		client := srv.Client()
+		client.Timeout = 20 * time.Millisecond
+
+		req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, srv.URL, nil)
+		require.NoError(t, err)
+
+		resp, err := testRetryPolicy.do(client, req)
+		require.Error(t, err)
+		require.Nil(t, resp)
+		require.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("does not retry network timeouts", func(t *testing.T) {
+		t.Parallel()
+
+		var calls atomic.Int32
+		client := &http.Client{Transport: roundTripFunc(func(*http.Request) (*http.Response, error) {
+			calls.Add(1)
+			return nil, timeoutError{}
+		})}
+
+		req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, "http://example.invalid", nil)
+		require.NoError(t, err)
+
+		resp, err := testRetryPolicy.do(client, req)
+		require.Error(t, err)
+		require.Nil(t, resp)
+		require.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("retries connection resets", func(t *testing.T) {
+		t.Parallel()
+
+		var calls atomic.Int32
+		client := &http.Client{Transport: roundTripFunc(func(*http.Request) (*http.Response, error) {
+			if calls.Add(1) < 3 {
+				return nil, &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET}
+			}
+			return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+		})}
+
+		req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, "http://example.invalid", nil)
+		require.NoError(t, err)
+
+		resp, err := testRetryPolicy.do(client, req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Equal(t, int32(3), calls.Load())
+	})
+
+	t.Run("does not retry permanent errors", func(t *testing.T) {
+		t.Parallel()
+
+		for name, permanent := range map[string]error{
+			"dns":    &net.DNSError{Err: "no such host", Name: "example.invalid", IsNotFound: true},
+			"tls":    x509.UnknownAuthorityError{},
+			"scheme": errors.New(`unsupported protocol scheme "ftp"`),
+		} {
+			t.Run(name, func(t *testing.T) {
+				t.Parallel()
+
+				var calls atomic.Int32
+				client := &http.Client{Transport: roundTripFunc(func(*http.Request) (*http.Response, error) {
+					calls.Add(1)
+					return nil, permanent
+				})}
+
+				req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, "http://example.invalid", nil)
+				require.NoError(t, err)
+
+				resp, err := testRetryPolicy.do(client, req)
+				require.Error(t, err)
+				require.Nil(t, resp)
+				require.Equal(t, int32(1), calls.Load())
+			})
+		}
+	})
+}
+
+func TestRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		header string
+		want   time.Duration
+		ok     bool
+	}{
+		{"", 0, false},
+		{"5", 5 * time.Second, true},
+		{"-1", 0, true},
+		{"Wed, 01 Jan 2025 12:00:30 GMT", 30 * time.Second, true},
+		{"Wed, 01 Jan 2025 11:00:00 GMT", 0, true},
+		{"soon", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := retryAfter(tt.header, now)
+		require.Equal(t, tt.ok, ok, tt.header)
+		require.Equal(t, tt.want, got, tt.header)
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	t.Parallel()
+
+	p := retryPolicy{maxAttempts: 5, baseDelay: 100 * time.Millisecond, maxDelay: 300 * time.Millisecond}
+	for range 100 {
+		require.LessOrEqual(t, p.backoff(1), 100*time.Millisecond)
+		require.LessOrEqual(t, p.backoff(2), 200*time.Millisecond)
+		require.LessOrEqual(t, p.backoff(4), 300*time.Millisecond)
+	}
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+// timeoutError is a net.Error that reports a timeout without wrapping
+// context.DeadlineExceeded.
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
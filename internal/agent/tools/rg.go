@@ -5,6 +5,7 @@ import (
 	"log/slog"
 	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"sync"
 
@@ -51,3 +52,35 @@ func getRgSearchCmd(ctx context.Context, pattern, path, include string) *exec.Cm
 
 	return exec.CommandContext(ctx, name, args...)
 }
+
+func getRgCodeSearchCmd(ctx context.Context, pattern, path, include string, ignoreFiles []string) *exec.Cmd {
+	name := getRg()
+	if name == "" {
+		return nil
+	}
+	args := []string{"--json", "-H", "-n", "--max-count", strconv.Itoa(maxCodeSearchMatchesPerFile)}
+	// Later globs take precedence in ripgrep, so the exclusions go after the
+	// include glob to keep e.g. *.min.js out of an include of *.js.
+	if include != "" {
+		args = append(args, "--glob", include)
+	}
+	for _, dir := range codeSearchExcludedDirs {
+		args = append(args, "--glob", "!"+dir+"/")
+	}
+	// A leading slash anchors the glob to the working directory, which is
+	// the search root.
+	for _, dir := range codeSearchRootExcludedDirs {
+		args = append(args, "--glob", "!/"+dir+"/")
+	}
+	for _, file := range codeSearchExcludedFiles {
+		args = append(args, "--glob", "!"+file)
+	}
+	for _, ignoreFile := range ignoreFiles {
+		args = append(args, "--ignore-file", ignoreFile)
+	}
+	args = append(args, "--", pattern, ".")
+
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Dir = path
+	return cmd
+}
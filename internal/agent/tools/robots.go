@@ -0,0 +1,331 @@
+package tools
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"charm.land/fantasy"
+	"github.com/charmbracelet/crush/internal/config"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	// robotsCacheTTL is how long a parsed robots.txt is used before it is
+	// fetched again.
+	robotsCacheTTL = 24 * time.Hour
+	// defaultCrawlDelay is the minimum time between requests to a host when
+	// its robots.txt sets no crawl-delay.
+	defaultCrawlDelay = time.Second
+	// maxCrawlWait is the longest a request waits for the crawl-delay of its
+	// host before it is refused instead.
+	maxCrawlWait = 30 * time.Second
+	// maxRobotsSize is the size after which robots.txt is no longer read.
+	maxRobotsSize = 500 * 1024
+)
+
+var (
+	// ErrDisallowedByRobots is returned when the robots.txt of a host does
+	// not allow fetching a URL.
+	ErrDisallowedByRobots = errors.New("disallowed by robots.txt")
+	// ErrCrawlDelay is returned when honoring the crawl-delay of a host would
+	// mean waiting longer than maxCrawlWait.
+	ErrCrawlDelay = errors.New("crawl-delay of host not elapsed")
+)
+
+// robotsRule is a single allow or disallow line of robots.txt.
+type robotsRule struct {
+	allow   bool
+	pattern string
+}
+
+// robotsRules are the rules of robots.txt that apply to our user agent.
+type robotsRules struct {
+	rules      []robotsRule
+	crawlDelay time.Duration
+}
+
+// allowed reports whether path, including its query, may be fetched. The
+// longest matching rule wins, and allow wins ties.
+func (r *robotsRules) allowed(path string) bool {
+	if path == "/robots.txt" {
+		return true
+	}
+	allow, length := true, -1
+	for _, rule := range r.rules {
+		if !matchRobotsPattern(rule.pattern, path) {
+			continue
+		}
+		if l := len(rule.pattern); l > length || (l == length && rule.allow) {
+			allow, length = rule.allow, l
+		}
+	}
+	return allow
+}
+
+// matchRobotsPattern matches path against a robots.txt path pattern, where
+// "*" matches any sequence of characters and a trailing "$" anchors the
+// pattern to the end of the path.
+func matchRobotsPattern(pattern, path string) bool {
+	anchored := strings.HasSuffix(pattern, "$")
+	pattern = strings.TrimSuffix(pattern, "$")
+	parts := strings.Split(pattern, "*")
+	if !strings.HasPrefix(path, parts[0]) {
+		return false
+	}
+	rest := path[len(parts[0]):]
+	for i, part := range parts[1:] {
+		if anchored && i == len(parts)-2 {
+			return strings.HasSuffix(rest, part)
+		}
+		idx := strings.Index(rest, part)
+		if idx < 0 {
+			return false
+		}
+		rest = rest[idx+len(part):]
+	}
+	return !anchored || rest == ""
+}
+
+// robotsGroup is a group of robots.txt rules and the user agents it names.
+type robotsGroup struct {
+	agents     []string
+	rules      []robotsRule
+	crawlDelay time.Duration
+	hasDelay   bool
+}
+
+// parseRobots parses robots.txt and returns the rules that apply to agent.
+// Groups naming the agent take precedence over the "*" group.
+func parseRobots(r io.Reader, agent string) *robotsRules {
+	var groups []*robotsGroup
+	var current *robotsGroup
+	inAgents := false
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+
+		if key == "user-agent" {
+			if !inAgents {
+				current = &robotsGroup{}
+				groups = append(groups, current)
+				inAgents = true
+			}
+			current.agents = append(current.agents, strings.ToLower(value))
+			continue
+		}
+		inAgents = false
+		if current == nil {
+			continue
+		}
+
+		switch key {
+		case "allow", "disallow":
+			if value != "" {
+				current.rules = append(current.rules, robotsRule{allow: key == "allow", pattern: value})
+			}
+		case "crawl-delay":
+			if seconds, err := strconv.ParseFloat(value, 64); err == nil && seconds >= 0 {
+				current.crawlDelay = time.Duration(seconds * float64(time.Second))
+				current.hasDelay = true
+			}
+		}
+	}
+
+	agent = strings.ToLower(agent)
+	matches := func(name string) bool {
+		return name != "" && name != "*" && strings.HasPrefix(agent, name)
+	}
+	selected := slices.DeleteFunc(slices.Clone(groups), func(g *robotsGroup) bool {
+		return !slices.ContainsFunc(g.agents, matches)
+	})
+	if len(selected) == 0 {
+		selected = slices.DeleteFunc(groups, func(g *robotsGroup) bool {
+			return !slices.Contains(g.agents, "*")
+		})
+	}
+
+	rules := &robotsRules{crawlDelay: defaultCrawlDelay}
+	for _, g := range selected {
+		rules.rules = append(rules.rules, g.rules...)
+		if g.hasDelay {
+			rules.crawlDelay = g.crawlDelay
+		}
+	}
+	return rules
+}
+
+// robotsHost holds the robots.txt rules of a host and when it may next be
+// requested.
+type robotsHost struct {
+	mu          sync.Mutex
+	rules       *robotsRules
+	fetchedAt   time.Time
+	nextRequest time.Time
+}
+
+// robotsChecker checks requests against robots.txt and spaces out requests
+// to the same host by its crawl-delay.
+type robotsChecker struct {
+	mu    sync.Mutex
+	hosts map[string]*robotsHost
+	// fetches makes concurrent requests to a host share one robots.txt
+	// fetch, done without holding the host's lock.
+	fetches singleflight.Group
+}
+
+// sharedRobots is used by all the network tools so that rules and crawl
+// delays are shared across tools and calls.
+var sharedRobots = &robotsChecker{hosts: make(map[string]*robotsHost)}
+
+// checkRobots checks rawURL against the robots.txt of its host and waits for
+// the host's crawl-delay. It does nothing unless respect_robots is enabled.
+func checkRobots(ctx context.Context, client *http.Client, cfg config.ToolHTTP, rawURL string) error {
+	if !cfg.RespectRobots {
+		return nil
+	}
+	return sharedRobots.check(ctx, client, cfg, rawURL)
+}
+
+func (c *robotsChecker) check(ctx context.Context, client *http.Client, cfg config.ToolHTTP, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("invalid URL: %w", err)
+	}
+	origin := u.Scheme + "://" + u.Host
+
+	c.mu.Lock()
+	host, ok := c.hosts[origin]
+	if !ok {
+		host = &robotsHost{}
+		c.hosts[origin] = host
+	}
+	c.mu.Unlock()
+
+	host.mu.Lock()
+	stale := host.rules == nil || time.Since(host.fetchedAt) > robotsCacheTTL
+	host.mu.Unlock()
+	if stale {
+		fetched, err, _ := c.fetches.Do(origin, func() (any, error) {
+			return fetchRobots(ctx, client, cfg, origin)
+		})
+		if err != nil {
+			return err
+		}
+		host.mu.Lock()
+		host.rules, host.fetchedAt = fetched.(*robotsRules), time.Now()
+		host.mu.Unlock()
+	}
+
+	host.mu.Lock()
+	rules := host.rules
+	if !rules.allowed(u.RequestURI()) {
+		host.mu.Unlock()
+		return fmt.Errorf("%w: %s", ErrDisallowedByRobots, rawURL)
+	}
+
+	now := time.Now()
+	wait := max(host.nextRequest.Sub(now), 0)
+	if wait > maxCrawlWait {
+		host.mu.Unlock()
+		return fmt.Errorf("%w: %s can be requested again in %s", ErrCrawlDelay, u.Host, wait.Round(time.Second))
+	}
+	host.nextRequest = now.Add(wait + rules.crawlDelay)
+	host.mu.Unlock()
+
+	if wait == 0 {
+		return nil
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// robotsClient returns client with a redirect policy that checks every
+// redirect target against robots.txt too. It returns client as-is unless
+// respect_robots is enabled.
+func robotsClient(client *http.Client, cfg config.ToolHTTP) *http.Client {
+	if !cfg.RespectRobots {
+		return client
+	}
+	checked := *client
+	checked.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if client.CheckRedirect != nil {
+			if err := client.CheckRedirect(req, via); err != nil {
+				return err
+			}
+		} else if len(via) >= 10 {
+			return errors.New("stopped after 10 redirects")
+		}
+		return checkRobots(req.Context(), client, cfg, req.URL.String())
+	}
+	return &checked
+}
+
+// fetchRobots fetches and parses the robots.txt of origin. A missing
+// robots.txt allows everything. A server error disallows everything for
+// now, as the host may be overloaded, and is not cached.
+func fetchRobots(ctx context.Context, client *http.Client, cfg config.ToolHTTP, origin string) (*robotsRules, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", origin+"/robots.txt", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create robots.txt request: %w", err)
+	}
+	setRequestHeaders(req, cfg)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch robots.txt: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return parseRobots(io.LimitReader(resp.Body, maxRobotsSize), robotsAgent(req.Header.Get("User-Agent"))), nil
+	case resp.StatusCode >= 400 && resp.StatusCode < 500:
+		return &robotsRules{crawlDelay: defaultCrawlDelay}, nil
+	default:
+		return nil, fmt.Errorf("%w: robots.txt of %s is unavailable (status %d)", ErrDisallowedByRobots, origin, resp.StatusCode)
+	}
+}
+
+// robotsAgent returns the product token of a User-Agent header, which is
+// what robots.txt user-agent lines are matched against.
+func robotsAgent(userAgent string) string {
+	token, _, _ := strings.Cut(userAgent, "/")
+	token, _, _ = strings.Cut(token, " ")
+	return token
+}
+
+// newRobotsErrorResponse returns the error response for a request refused
+// by checkRobots.
+func newRobotsErrorResponse(ctx context.Context, err error) (fantasy.ToolResponse, error) {
+	switch {
+	case errors.Is(err, ErrDisallowedByRobots):
+		return NewErrorResponse(ErrorKindAccessDenied, "Fetching this URL is not allowed: "+err.Error()), nil
+	case errors.Is(err, ErrCrawlDelay):
+		return NewErrorResponse(ErrorKindRateLimited, "Fetching this URL has to wait: "+err.Error()), nil
+	default:
+		return newRequestErrorResponse(ctx, err, "Failed to check robots.txt")
+	}
+}
@@ -0,0 +1,198 @@
+package tools
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/crush/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+const testRobots = `
+# comment
+User-agent: *
+Disallow: /private
+Allow: /private/public
+Disallow: /*.pdf$
+
+User-agent: otherbot
+User-agent: crush
+Disallow: /
+Allow: /docs
+Crawl-delay: 0.05
+`
+
+func TestParseRobots(t *testing.T) {
+	t.Parallel()
+
+	t.Run("wildcard group", func(t *testing.T) {
+		t.Parallel()
+
+		rules := parseRobots(strings.NewReader(testRobots), "somebot")
+		require.Equal(t, defaultCrawlDelay, rules.crawlDelay)
+		for path, allowed := range map[string]bool{
+			"/":                    true,
+			"/private":             false,
+			"/private/x":           false,
+			"/private/public/x":    true,
+			"/file.pdf":            false,
+			"/file.pdf?download=1": true,
+			"/robots.txt":          true,
+		} {
+			require.Equal(t, allowed, rules.allowed(path), path)
+		}
+	})
+
+	t.Run("group naming the agent", func(t *testing.T) {
+		t.Parallel()
+
+		rules := parseRobots(strings.NewReader(testRobots), "Crush")
+		require.Equal(t, 50*time.Millisecond, rules.crawlDelay)
+		require.False(t, rules.allowed("/"))
+		require.True(t, rules.allowed("/docs/index.html"))
+		require.True(t, rules.allowed("/robots.txt"))
+	})
+}
+
+func TestMatchRobotsPattern(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		pattern, path string
+		match         bool
+	}{
+		{"/", "/anything", true},
+		{"/fish", "/fish.html", true},
+		{"/fish", "/Fish", false},
+		{"/fish$", "/fish", true},
+		{"/fish$", "/fish/", false},
+		{"/*.php", "/index.php", true},
+		{"/*.php", "/folder/filename.php?params", true},
+		{"/*.php$", "/filename.php?params", false},
+		{"/fish*.php", "/fishheads/catfish.php", true},
+		{"/fish*.php", "/Fish.PHP", false},
+	} {
+		require.Equal(t, tt.match, matchRobotsPattern(tt.pattern, tt.path), "%s %s", tt.pattern, tt.path)
+	}
+}
+
+func TestRobotsChecker(t *testing.T) {
+	t.Parallel()
+
+	newChecker := func() *robotsChecker {
+		return &robotsChecker{hosts: make(map[string]*robotsHost)}
+	}
+	newServer := func(status int, body string) *httptest.Server {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/robots.txt" {
+				w.WriteHeader(http.StatusOK)
+				return
+			}
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte(body))
+		}))
+		t.Cleanup(srv.Close)
+		return srv
+	}
+	cfg := config.ToolHTTP{RespectRobots: true}
+
+	t.Run("honors rules and crawl-delay", func(t *testing.T) {
+		t.Parallel()
+
+		srv := newServer(http.StatusOK, testRobots)
+		c := newChecker()
+
+		err := c.check(t.Context(), srv.Client(), cfg, srv.URL+"/private")
+		require.ErrorIs(t, err, ErrDisallowedByRobots)
+
+		require.NoError(t, c.check(t.Context(), srv.Client(), cfg, srv.URL+"/docs"))
+		start := time.Now()
+		require.NoError(t, c.check(t.Context(), srv.Client(), config.ToolHTTP{RespectRobots: true, UserAgent: "crush/2.0"}, srv.URL+"/docs"))
+		require.GreaterOrEqual(t, time.Since(start), 40*time.Millisecond)
+	})
+
+	t.Run("missing robots.txt allows everything", func(t *testing.T) {
+		t.Parallel()
+
+		srv := newServer(http.StatusNotFound, "")
+		require.NoError(t, newChecker().check(t.Context(), srv.Client(), cfg, srv.URL+"/private"))
+	})
+
+	t.Run("unavailable robots.txt disallows everything", func(t *testing.T) {
+		t.Parallel()
+
+		srv := newServer(http.StatusServiceUnavailable, "")
+		err := newChecker().check(t.Context(), srv.Client(), cfg, srv.URL+"/")
+		require.ErrorIs(t, err, ErrDisallowedByRobots)
+	})
+
+	t.Run("refuses waits longer than the maximum", func(t *testing.T) {
+		t.Parallel()
+
+		srv := newServer(http.StatusOK, "User-agent: *\nCrawl-delay: 3600\n")
+		c := newChecker()
+		require.NoError(t, c.check(t.Context(), srv.Client(), cfg, srv.URL+"/a"))
+		err := c.check(t.Context(), srv.Client(), cfg, srv.URL+"/b")
+		require.ErrorIs(t, err, ErrCrawlDelay)
+	})
+
+	t.Run("disabled by default", func(t *testing.T) {
+		t.Parallel()
+
+		srv := newServer(http.StatusOK, "User-agent: *\nDisallow: /\n")
+		require.NoError(t, checkRobots(t.Context(), srv.Client(), config.ToolHTTP{}, srv.URL+"/"))
+	})
+
+	t.Run("shares one robots.txt fetch", func(t *testing.T) {
+		t.Parallel()
+
+		var fetches atomic.Int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fetches.Add(1)
+			time.Sleep(50 * time.Millisecond)
+			_, _ = w.Write([]byte("User-agent: *\nCrawl-delay: 0\n"))
+		}))
+		t.Cleanup(srv.Close)
+
+		c := newChecker()
+		var wg sync.WaitGroup
+		for range 5 {
+			wg.Go(func() {
+				require.NoError(t, c.check(t.Context(), srv.Client(), cfg, srv.URL+"/"))
+			})
+		}
+		wg.Wait()
+		require.Equal(t, int32(1), fetches.Load())
+	})
+}
+
+func TestRobotsClient(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/robots.txt":
+			_, _ = w.Write([]byte("User-agent: *\nDisallow: /private\nCrawl-delay: 0\n"))
+		case "/start":
+			http.Redirect(w, r, "/private", http.StatusFound)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	client := robotsClient(srv.Client(), config.ToolHTTP{RespectRobots: true})
+	_, err := client.Get(srv.URL + "/start")
+	require.ErrorIs(t, err, ErrDisallowedByRobots)
+
+	// Without respect_robots the client is left alone.
+	resp, err := robotsClient(srv.Client(), config.ToolHTTP{}).Get(srv.URL + "/start")
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+}
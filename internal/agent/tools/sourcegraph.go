@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"charm.land/fantasy"
+	"github.com/charmbracelet/crush/internal/config"
 )
 
 type SourcegraphParams struct {
@@ -31,23 +32,20 @@ const SourcegraphToolName = "sourcegraph"
 //go:embed sourcegraph.md
 var sourcegraphDescription []byte
 
-func NewSourcegraphTool(client *http.Client) fantasy.AgentTool {
+func NewSourcegraphTool(httpCfg config.ToolHTTP, client *http.Client) fantasy.AgentTool {
 	if client == nil {
-		client = &http.Client{
-			Timeout: 30 * time.Second,
-			Transport: &http.Transport{
-				MaxIdleConns:        100,
-				MaxIdleConnsPerHost: 10,
-				IdleConnTimeout:     90 * time.Second,
-			},
-		}
+		client = NewHTTPClient(30 * time.Second)
 	}
 	return fantasy.NewAgentTool(
 		SourcegraphToolName,
 		string(sourcegraphDescription),
 		func(ctx context.Context, params SourcegraphParams, call fantasy.ToolCall) (fantasy.ToolResponse, error) {
+			if err := CheckNetworkPolicy(ctx, httpCfg, SourcegraphToolName); err != nil {
+				return NewErrorResponse(ErrorKindNetworkPolicy, err.Error()), nil
+			}
+
 			if params.Query == "" {
-				return fantasy.NewTextErrorResponse("Query parameter is required"), nil
+				return NewErrorResponse(ErrorKindInvalidArgs, "Query parameter is required"), nil
 			}
 
 			if params.Count <= 0 {
@@ -100,22 +98,22 @@ func NewSourcegraphTool(client *http.Client) fantasy.AgentTool {
 				return fantasy.ToolResponse{}, fmt.Errorf("failed to create request: %w", err)
 			}
 
+			setRequestHeaders(req, httpCfg)
 			req.Header.Set("Content-Type", "application/json")
-			req.Header.Set("User-Agent", "crush/1.0")
 
-			resp, err := client.Do(req)
+			resp, err := doWithRetry(client, req)
 			if err != nil {
-				return fantasy.ToolResponse{}, fmt.Errorf("failed to fetch URL: %w", err)
+				return newRequestErrorResponse(ctx, err, "Failed to fetch URL")
 			}
 			defer resp.Body.Close()
 
 			if resp.StatusCode != http.StatusOK {
 				body, _ := io.ReadAll(resp.Body)
 				if len(body) > 0 {
-					return fantasy.NewTextErrorResponse(fmt.Sprintf("Request failed with status code: %d, response: %s", resp.StatusCode, string(body))), nil
+					return newHTTPStatusErrorResponse(resp.StatusCode, fmt.Sprintf("Request failed with status code: %d, response: %s", resp.StatusCode, string(body))), nil
 				}
 
-				return fantasy.NewTextErrorResponse(fmt.Sprintf("Request failed with status code: %d", resp.StatusCode)), nil
+				return newHTTPStatusErrorResponse(resp.StatusCode, fmt.Sprintf("Request failed with status code: %d", resp.StatusCode)), nil
 			}
 			body, err := io.ReadAll(resp.Body)
 			if err != nil {
@@ -129,7 +127,7 @@ func NewSourcegraphTool(client *http.Client) fantasy.AgentTool {
 
 			formattedResults, err := formatSourcegraphResults(result, params.ContextWindow)
 			if err != nil {
-				return fantasy.NewTextErrorResponse("Failed to format results: " + err.Error()), nil
+				return NewErrorResponse(ErrorKindInternal, "Failed to format results: "+err.Error()), nil
 			}
 
 			return fantasy.NewTextResponse(formattedResults), nil
@@ -5,13 +5,24 @@ import (
 )
 
 type (
-	sessionIDContextKey string
-	messageIDContextKey string
+	sessionIDContextKey   string
+	messageIDContextKey   string
+	sessionKindContextKey string
 )
 
 const (
-	SessionIDContextKey sessionIDContextKey = "session_id"
-	MessageIDContextKey messageIDContextKey = "message_id"
+	SessionIDContextKey   sessionIDContextKey   = "session_id"
+	MessageIDContextKey   messageIDContextKey   = "message_id"
+	SessionKindContextKey sessionKindContextKey = "session_kind"
+)
+
+// SessionKind tells sessions driven by a user apart from those started
+// non-interactively.
+type SessionKind string
+
+const (
+	SessionInteractive    SessionKind = "interactive"
+	SessionNonInteractive SessionKind = "non_interactive"
 )
 
 func GetSessionFromContext(ctx context.Context) string {
@@ -37,3 +48,13 @@ func GetMessageFromContext(ctx context.Context) string {
 	}
 	return s
 }
+
+// GetSessionKindFromContext returns the kind of session a tool runs in.
+// Sessions are interactive unless marked otherwise.
+func GetSessionKindFromContext(ctx context.Context) SessionKind {
+	kind, ok := ctx.Value(SessionKindContextKey).(SessionKind)
+	if !ok || kind == "" {
+		return SessionInteractive
+	}
+	return kind
+}
@@ -57,7 +57,7 @@ func NewViewTool(lspClients *csync.Map[string, *lsp.Client], permissions permiss
 		string(viewDescription),
 		func(ctx context.Context, params ViewParams, call fantasy.ToolCall) (fantasy.ToolResponse, error) {
 			if params.FilePath == "" {
-				return fantasy.NewTextErrorResponse("file_path is required"), nil
+				return NewErrorResponse(ErrorKindInvalidArgs, "file_path is required"), nil
 			}
 
 			// Handle relative paths
@@ -121,24 +121,24 @@ func NewViewTool(lspClients *csync.Map[string, *lsp.Client], permissions permiss
 						}
 
 						if len(suggestions) > 0 {
-							return fantasy.NewTextErrorResponse(fmt.Sprintf("File not found: %s\n\nDid you mean one of these?\n%s",
+							return NewErrorResponse(ErrorKindNotFound, fmt.Sprintf("File not found: %s\n\nDid you mean one of these?\n%s",
 								filePath, strings.Join(suggestions, "\n"))), nil
 						}
 					}
 
-					return fantasy.NewTextErrorResponse(fmt.Sprintf("File not found: %s", filePath)), nil
+					return NewErrorResponse(ErrorKindNotFound, fmt.Sprintf("File not found: %s", filePath)), nil
 				}
 				return fantasy.ToolResponse{}, fmt.Errorf("error accessing file: %w", err)
 			}
 
 			// Check if it's a directory
 			if fileInfo.IsDir() {
-				return fantasy.NewTextErrorResponse(fmt.Sprintf("Path is a directory, not a file: %s", filePath)), nil
+				return NewErrorResponse(ErrorKindInvalidArgs, fmt.Sprintf("Path is a directory, not a file: %s", filePath)), nil
 			}
 
 			// Check file size
 			if fileInfo.Size() > MaxReadSize {
-				return fantasy.NewTextErrorResponse(fmt.Sprintf("File is too large (%d bytes). Maximum size is %d bytes",
+				return NewErrorResponse(ErrorKindLimitExceeded, fmt.Sprintf("File is too large (%d bytes). Maximum size is %d bytes",
 					fileInfo.Size(), MaxReadSize)), nil
 			}
 
@@ -151,14 +151,14 @@ func NewViewTool(lspClients *csync.Map[string, *lsp.Client], permissions permiss
 			isImage, imageType := isImageFile(filePath)
 			// TODO: handle images
 			if isImage {
-				return fantasy.NewTextErrorResponse(fmt.Sprintf("This is an image file of type: %s\n", imageType)), nil
+				return NewErrorResponse(ErrorKindUnsupportedFormat, fmt.Sprintf("This is an image file of type: %s\n", imageType)), nil
 			}
 
 			// Read the file content
 			content, lineCount, err := readTextFile(filePath, params.Offset, params.Limit)
 			isValidUt8 := utf8.ValidString(content)
 			if !isValidUt8 {
-				return fantasy.NewTextErrorResponse("File content is not valid UTF-8"), nil
+				return NewErrorResponse(ErrorKindUnsupportedFormat, "File content is not valid UTF-8"), nil
 			}
 			if err != nil {
 				return fantasy.ToolResponse{}, fmt.Errorf("error reading file: %w", err)
@@ -3,6 +3,7 @@ package tools
 import (
 	"context"
 	_ "embed"
+	"errors"
 	"fmt"
 	"net/http"
 	"os"
@@ -10,35 +11,37 @@ import (
 	"time"
 
 	"charm.land/fantasy"
+	"github.com/charmbracelet/crush/internal/config"
 )
 
 //go:embed web_fetch.md
 var webFetchToolDescription []byte
 
 // NewWebFetchTool creates a simple web fetch tool for sub-agents (no permissions needed).
-func NewWebFetchTool(workingDir string, client *http.Client) fantasy.AgentTool {
+func NewWebFetchTool(workingDir string, httpCfg config.ToolHTTP, client *http.Client) fantasy.AgentTool {
 	if client == nil {
-		client = &http.Client{
-			Timeout: 30 * time.Second,
-			Transport: &http.Transport{
-				MaxIdleConns:        100,
-				MaxIdleConnsPerHost: 10,
-				IdleConnTimeout:     90 * time.Second,
-			},
-		}
+		client = NewHTTPClient(30 * time.Second)
 	}
 
 	return fantasy.NewAgentTool(
 		WebFetchToolName,
 		string(webFetchToolDescription),
 		func(ctx context.Context, params WebFetchParams, call fantasy.ToolCall) (fantasy.ToolResponse, error) {
+			// web_fetch only runs inside agentic_fetch, so it shares its policy.
+			if err := CheckNetworkPolicy(ctx, httpCfg, AgenticFetchToolName); err != nil {
+				return NewErrorResponse(ErrorKindNetworkPolicy, err.Error()), nil
+			}
+
 			if params.URL == "" {
-				return fantasy.NewTextErrorResponse("url is required"), nil
+				return NewErrorResponse(ErrorKindInvalidArgs, "url is required"), nil
 			}
 
-			content, err := FetchURLAndConvert(ctx, client, params.URL)
+			content, err := FetchURLAndConvert(ctx, client, httpCfg, params.URL)
+			if errors.Is(err, ErrDisallowedByRobots) || errors.Is(err, ErrCrawlDelay) {
+				return newRobotsErrorResponse(ctx, err)
+			}
 			if err != nil {
-				return fantasy.NewTextErrorResponse(fmt.Sprintf("Failed to fetch URL: %s", err)), nil
+				return NewErrorResponse(ErrorKindNetwork, fmt.Sprintf("Failed to fetch URL: %s", err)), nil
 			}
 
 			hasLargeContent := len(content) > LargeContentThreshold
@@ -47,16 +50,16 @@ func NewWebFetchTool(workingDir string, client *http.Client) fantasy.AgentTool {
 			if hasLargeContent {
 				tempFile, err := os.CreateTemp(workingDir, "page-*.md")
 				if err != nil {
-					return fantasy.NewTextErrorResponse(fmt.Sprintf("Failed to create temporary file: %s", err)), nil
+					return NewErrorResponse(ErrorKindInternal, fmt.Sprintf("Failed to create temporary file: %s", err)), nil
 				}
 				tempFilePath := tempFile.Name()
 
 				if _, err := tempFile.WriteString(content); err != nil {
 					_ = tempFile.Close() // Best effort close
-					return fantasy.NewTextErrorResponse(fmt.Sprintf("Failed to write content to file: %s", err)), nil
+					return NewErrorResponse(ErrorKindInternal, fmt.Sprintf("Failed to write content to file: %s", err)), nil
 				}
 				if err := tempFile.Close(); err != nil {
-					return fantasy.NewTextErrorResponse(fmt.Sprintf("Failed to close temporary file: %s", err)), nil
+					return NewErrorResponse(ErrorKindInternal, fmt.Sprintf("Failed to close temporary file: %s", err)), nil
 				}
 
 				result.WriteString(fmt.Sprintf("Fetched content from %s (large page)\n\n", params.URL))
@@ -56,11 +56,11 @@ func NewWriteTool(lspClients *csync.Map[string, *lsp.Client], permissions permis
 		string(writeDescription),
 		func(ctx context.Context, params WriteParams, call fantasy.ToolCall) (fantasy.ToolResponse, error) {
 			if params.FilePath == "" {
-				return fantasy.NewTextErrorResponse("file_path is required"), nil
+				return NewErrorResponse(ErrorKindInvalidArgs, "file_path is required"), nil
 			}
 
 			if params.Content == "" {
-				return fantasy.NewTextErrorResponse("content is required"), nil
+				return NewErrorResponse(ErrorKindInvalidArgs, "content is required"), nil
 			}
 
 			filePath := filepathext.SmartJoin(workingDir, params.FilePath)
@@ -68,19 +68,19 @@ func NewWriteTool(lspClients *csync.Map[string, *lsp.Client], permissions permis
 			fileInfo, err := os.Stat(filePath)
 			if err == nil {
 				if fileInfo.IsDir() {
-					return fantasy.NewTextErrorResponse(fmt.Sprintf("Path is a directory, not a file: %s", filePath)), nil
+					return NewErrorResponse(ErrorKindInvalidArgs, fmt.Sprintf("Path is a directory, not a file: %s", filePath)), nil
 				}
 
 				modTime := fileInfo.ModTime()
 				lastRead := getLastReadTime(filePath)
 				if modTime.After(lastRead) {
-					return fantasy.NewTextErrorResponse(fmt.Sprintf("File %s has been modified since it was last read.\nLast modification: %s\nLast read: %s\n\nPlease read the file again before modifying it.",
+					return NewErrorResponse(ErrorKindConflict, fmt.Sprintf("File %s has been modified since it was last read.\nLast modification: %s\nLast read: %s\n\nPlease read the file again before modifying it.",
 						filePath, modTime.Format(time.RFC3339), lastRead.Format(time.RFC3339))), nil
 				}
 
 				oldContent, readErr := os.ReadFile(filePath)
 				if readErr == nil && string(oldContent) == params.Content {
-					return fantasy.NewTextErrorResponse(fmt.Sprintf("File %s already contains the exact content. No changes made.", filePath)), nil
+					return NewErrorResponse(ErrorKindInvalidArgs, fmt.Sprintf("File %s already contains the exact content. No changes made.", filePath)), nil
 				}
 			} else if !os.IsNotExist(err) {
 				return fantasy.ToolResponse{}, fmt.Errorf("error checking file: %w", err)
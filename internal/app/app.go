@@ -17,6 +17,7 @@ import (
 	"charm.land/fantasy"
 	"charm.land/lipgloss/v2"
 	"github.com/charmbracelet/crush/internal/agent"
+	"github.com/charmbracelet/crush/internal/agent/tools"
 	"github.com/charmbracelet/crush/internal/agent/tools/mcp"
 	"github.com/charmbracelet/crush/internal/config"
 	"github.com/charmbracelet/crush/internal/csync"
@@ -122,10 +123,13 @@ func (app *App) Config() *config.Config {
 }
 
 // RunNonInteractive runs the application in non-interactive mode with the
-// given prompt, printing to stdout.
-func (app *App) RunNonInteractive(ctx context.Context, output io.Writer, prompt string, quiet bool) error {
+// given prompt, printing to stdout. A non-negative maxTurns overrides the
+// configured turn limit for this run.
+func (app *App) RunNonInteractive(ctx context.Context, output io.Writer, prompt string, quiet bool, maxTurns int) error {
 	slog.Info("Running in non-interactive mode")
 
+	// Let tools apply the network policy for non-interactive sessions.
+	ctx = context.WithValue(ctx, tools.SessionKindContextKey, tools.SessionNonInteractive)
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
@@ -183,6 +187,10 @@ func (app *App) RunNonInteractive(ctx context.Context, output io.Writer, prompt
 	// session.
 	app.Permissions.AutoApproveSession(sess.ID)
 
+	if maxTurns >= 0 {
+		app.AgentCoordinator.SetSessionMaxTurns(sess.ID, maxTurns)
+	}
+
 	type response struct {
 		result *fantasy.AgentResult
 		err    error
@@ -26,9 +26,17 @@ crush run "What is this code doing?" <<< prrr.go
 
 # Run in quiet mode (hide the spinner)
 crush run --quiet "Generate a README for this project"
+
+# Stop after at most 10 turns
+crush run --max-turns 10 "Find and fix the failing test"
   `,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		quiet, _ := cmd.Flags().GetBool("quiet")
+		// A negative limit leaves the configured max_turns options in place.
+		maxTurns := -1
+		if cmd.Flags().Changed("max-turns") {
+			maxTurns, _ = cmd.Flags().GetInt("max-turns")
+		}
 
 		app, err := setupApp(cmd)
 		if err != nil {
@@ -58,10 +66,11 @@ crush run --quiet "Generate a README for this project"
 		//     echo "Do something fancy" | crush run > output.txt
 		//
 		// TODO: We currently need to press ^c twice to cancel. Fix that.
-		return app.RunNonInteractive(cmd.Context(), os.Stdout, prompt, quiet)
+		return app.RunNonInteractive(cmd.Context(), os.Stdout, prompt, quiet, maxTurns)
 	},
 }
 
 func init() {
 	runCmd.Flags().BoolP("quiet", "q", false, "Hide spinner")
+	runCmd.Flags().Int("max-turns", 0, "Maximum number of agent turns for this prompt, overriding the max_turns options (0 means no limit)")
 }
@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/charmbracelet/crush/internal/agent"
+	"github.com/spf13/cobra"
+)
+
+var sessionsCmd = &cobra.Command{
+	Use:   "sessions",
+	Short: "Inspect sessions",
+	Long:  `Inspect the sessions stored for the current project.`,
+}
+
+var sessionsInspectCmd = &cobra.Command{
+	Use:   "inspect [session-id]",
+	Short: "Show details about a session",
+	Long: `Show details about a session. When no session ID is given, the most
+recently updated session is used.
+
+With --tokens, show how the prompt for the next request in the session is
+composed, with an estimated token count for each component. Attachments
+are marked with a tilde: their counts are rough per-image or per-file
+estimates rather than measured from their contents.`,
+	Example: `
+# Show details about the latest session
+crush sessions inspect
+
+# Show the prompt token breakdown of a specific session
+crush sessions inspect --tokens 4f1b2c3d-...
+  `,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		showTokens, _ := cmd.Flags().GetBool("tokens")
+
+		app, err := setupApp(cmd)
+		if err != nil {
+			return err
+		}
+		defer app.Shutdown()
+
+		var sessionID string
+		if len(args) > 0 {
+			sessionID = args[0]
+		} else {
+			all, err := app.Sessions.List(cmd.Context())
+			if err != nil {
+				return fmt.Errorf("failed to list sessions: %w", err)
+			}
+			if len(all) == 0 {
+				return errors.New("no sessions found")
+			}
+			latest := all[0]
+			for _, s := range all[1:] {
+				if s.UpdatedAt > latest.UpdatedAt {
+					latest = s
+				}
+			}
+			sessionID = latest.ID
+		}
+
+		sess, err := app.Sessions.Get(cmd.Context(), sessionID)
+		if err != nil {
+			return fmt.Errorf("failed to get session: %w", err)
+		}
+
+		w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+		fmt.Fprintf(w, "ID\t%s\n", sess.ID)
+		fmt.Fprintf(w, "Title\t%s\n", sess.Title)
+		fmt.Fprintf(w, "Updated\t%s\n", time.Unix(sess.UpdatedAt, 0).Format(time.DateTime))
+		fmt.Fprintf(w, "Messages\t%d\n", sess.MessageCount)
+		fmt.Fprintf(w, "Tokens\t%d prompt, %d completion\n", sess.PromptTokens, sess.CompletionTokens)
+		fmt.Fprintf(w, "Cost\t$%.4f\n", sess.Cost)
+		if err := w.Flush(); err != nil {
+			return err
+		}
+
+		if !showTokens {
+			return nil
+		}
+
+		if !app.Config().IsConfigured() {
+			return errors.New("no providers configured - please run 'crush' to set up a provider interactively")
+		}
+		breakdown, err := app.AgentCoordinator.InspectPrompt(cmd.Context(), sess.ID)
+		if err != nil {
+			return err
+		}
+		cmd.Println()
+		return writePromptBreakdown(cmd.OutOrStdout(), breakdown)
+	},
+}
+
+func writePromptBreakdown(out io.Writer, b agent.PromptBreakdown) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "Component\tKind\tTokens\t\n")
+	for _, c := range b.Components {
+		if c.Kind == agent.PromptComponentAttachment {
+			fmt.Fprintf(w, "%s\t%s\t~%d\t\n", c.Name, c.Kind, c.Tokens)
+			continue
+		}
+		fmt.Fprintf(w, "%s\t%s\t%d\t\n", c.Name, c.Kind, c.Tokens)
+	}
+	fmt.Fprintf(w, "\t\t\t\n")
+	for _, kind := range []agent.PromptComponentKind{
+		agent.PromptComponentSystem,
+		agent.PromptComponentSummary,
+		agent.PromptComponentMessage,
+		agent.PromptComponentTool,
+	} {
+		fmt.Fprintf(w, "Total %s\t\t%d\t\n", kind, b.TotalFor(kind))
+	}
+	fmt.Fprintf(w, "Total attachment (rough estimate)\t\t~%d\t\n", b.TotalFor(agent.PromptComponentAttachment))
+	fmt.Fprintf(w, "Estimated total\t\t%d\t\n", b.Total())
+	fmt.Fprintf(w, "Last reported prompt\t\t%d\t\n", b.ReportedTokens)
+	if b.ContextWindow > 0 {
+		fmt.Fprintf(w, "Context window\t\t%d\t\n", b.ContextWindow)
+	}
+	return w.Flush()
+}
+
+func init() {
+	sessionsInspectCmd.Flags().Bool("tokens", false, "Show the prompt token breakdown")
+	sessionsCmd.AddCommand(sessionsInspectCmd)
+}
@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/crush/internal/filepathext"
+	"github.com/charmbracelet/crush/internal/worklog"
+	"github.com/spf13/cobra"
+)
+
+var worklogCmd = &cobra.Command{
+	Use:   "worklog",
+	Short: "Generate a worklog entry from recent sessions",
+	Long: `Generate a human-readable worklog entry from the files edited in recent
+sessions and the git commits made over the same period. The entry is written
+by the configured model and prepended to the worklog file.`,
+	Example: `
+# Write an entry for the last 24 hours to the configured worklog file
+crush worklog
+
+# Cover the last week and write to CHANGELOG.md
+crush worklog --since 168h --output CHANGELOG.md
+
+# Print the entry instead of writing it
+crush worklog --print
+  `,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		since, _ := cmd.Flags().GetDuration("since")
+		output, _ := cmd.Flags().GetString("output")
+		printOnly, _ := cmd.Flags().GetBool("print")
+
+		app, err := setupApp(cmd)
+		if err != nil {
+			return err
+		}
+		defer app.Shutdown()
+
+		cfg := app.Config()
+		if !cfg.IsConfigured() {
+			return fmt.Errorf("no providers configured - please run 'crush' to set up a provider interactively")
+		}
+
+		now := time.Now()
+		activity, err := worklog.Collect(cmd.Context(), app.Sessions, app.History, cfg.WorkingDir(), now.Add(-since))
+		if err != nil {
+			return err
+		}
+		if activity.Empty() {
+			cmd.Println("No activity found, nothing to write.")
+			return nil
+		}
+
+		entry, err := app.AgentCoordinator.Worklog(cmd.Context(), activity.String())
+		if err != nil {
+			return err
+		}
+
+		if printOnly {
+			cmd.Println(entry)
+			return nil
+		}
+
+		if output == "" {
+			output = cfg.Options.WorklogFile
+		}
+		path := filepathext.SmartJoin(cfg.WorkingDir(), output)
+		if err := worklog.Write(path, now, entry); err != nil {
+			return err
+		}
+		cmd.Printf("Worklog entry written to %s\n", path)
+		return nil
+	},
+}
+
+func init() {
+	worklogCmd.Flags().Duration("since", 24*time.Hour, "How far back to look for activity")
+	worklogCmd.Flags().StringP("output", "o", "", "File to write the entry to (defaults to the worklog_file option)")
+	worklogCmd.Flags().BoolP("print", "p", false, "Print the entry instead of writing it")
+}
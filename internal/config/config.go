@@ -22,6 +22,7 @@ const (
 	appName              = "crush"
 	defaultDataDirectory = ".crush"
 	defaultInitializeAs  = "AGENTS.md"
+	defaultWorklogFile   = "WORKLOG.md"
 )
 
 var defaultContextPaths = []string{
@@ -105,6 +106,9 @@ type ProviderConfig struct {
 
 	ProviderOptions map[string]any `json:"provider_options,omitempty" jsonschema:"description=Additional provider-specific options for this provider"`
 
+	// Maximum number of requests in flight to the provider, 0 means unlimited.
+	MaxConcurrentRequests int `json:"max_concurrent_requests,omitempty" jsonschema:"description=Maximum number of concurrent requests to this provider; further requests wait in a queue (0 means unlimited),default=0,minimum=0,example=2"`
+
 	// Used to pass extra parameters to the provider.
 	ExtraParams map[string]string `json:"-"`
 
@@ -147,6 +151,7 @@ type LSPConfig struct {
 type TUIOptions struct {
 	CompactMode bool   `json:"compact_mode,omitempty" jsonschema:"description=Enable compact mode for the TUI interface,default=false"`
 	DiffMode    string `json:"diff_mode,omitempty" jsonschema:"description=Diff mode for the TUI interface,enum=unified,enum=split"`
+	Accessible  bool   `json:"accessible,omitempty" jsonschema:"description=Render plain text without box-drawing borders, status glyphs or color-only signals, for screen readers and dumb terminals,default=false"`
 	// Here we can add themes later or any TUI related options
 	//
 
@@ -203,6 +208,12 @@ type Options struct {
 	Attribution               *Attribution `json:"attribution,omitempty" jsonschema:"description=Attribution settings for generated content"`
 	DisableMetrics            bool         `json:"disable_metrics,omitempty" jsonschema:"description=Disable sending metrics,default=false"`
 	InitializeAs              string       `json:"initialize_as,omitempty" jsonschema:"description=Name of the context file to create/update during project initialization,default=AGENTS.md,example=AGENTS.md,example=CRUSH.md,example=CLAUDE.md,example=docs/LLMs.md"`
+	WorklogFile               string       `json:"worklog_file,omitempty" jsonschema:"description=File that crush worklog writes entries to (relative to working directory),default=WORKLOG.md,example=WORKLOG.md,example=CHANGELOG.md,example=docs/worklog.md"`
+	MaxTurns                  int          `json:"max_turns,omitempty" jsonschema:"description=Maximum number of agent turns (model calls and tool-call rounds) per request; 0 means no limit,default=0,minimum=0,example=25"`
+	InteractiveMaxTurns       int          `json:"interactive_max_turns,omitempty" jsonschema:"description=Maximum number of agent turns per request in interactive sessions; 0 falls back to max_turns,default=0,minimum=0,example=10"`
+	NonInteractiveMaxTurns    int          `json:"non_interactive_max_turns,omitempty" jsonschema:"description=Maximum number of agent turns per request in non-interactive sessions such as crush run; 0 falls back to max_turns,default=0,minimum=0,example=50"`
+	SessionCostWarning        float64      `json:"session_cost_warning,omitempty" jsonschema:"description=Session cost in USD above which a warning is shown; 0 disables the warning,default=0,minimum=0,example=1"`
+	SessionCostLimit          float64      `json:"session_cost_limit,omitempty" jsonschema:"description=Session cost in USD at which the agent is paused until the limit is raised; 0 means no limit,default=0,minimum=0,example=5"`
 }
 
 type MCPs map[string]MCPConfig
@@ -292,7 +303,8 @@ type Agent struct {
 }
 
 type Tools struct {
-	Ls ToolLs `json:"ls,omitzero"`
+	Ls   ToolLs   `json:"ls,omitzero"`
+	HTTP ToolHTTP `json:"http,omitzero"`
 }
 
 type ToolLs struct {
@@ -304,6 +316,34 @@ func (t ToolLs) Limits() (depth, items int) {
 	return ptrValOr(t.MaxDepth, 0), ptrValOr(t.MaxItems, 0)
 }
 
+// ToolHTTP configures the requests made by the tools that reach out to the
+// network (fetch, download, sourcegraph and agentic_fetch).
+type ToolHTTP struct {
+	UserAgent string            `json:"user_agent,omitempty" jsonschema:"description=User-Agent header sent by network tools,default=crush/1.0,example=Mozilla/5.0 (compatible; crush)"`
+	Headers   map[string]string `json:"headers,omitempty" jsonschema:"description=Additional HTTP headers sent by network tools"`
+	// RespectRobots makes the page fetching tools honor robots.txt rules and
+	// crawl-delay, spacing out requests to the same host.
+	RespectRobots bool `json:"respect_robots,omitempty" jsonschema:"description=Check robots.txt before fetching pages and honor its rules and crawl-delay,default=false"`
+	// Offline blocks all network tools, in every kind of session.
+	Offline        bool          `json:"offline,omitempty" jsonschema:"description=Block all network tools; they return a policy error instead of making requests,default=false"`
+	Interactive    NetworkPolicy `json:"interactive,omitzero" jsonschema:"description=Network policy for interactive sessions"`
+	NonInteractive NetworkPolicy `json:"non_interactive,omitzero" jsonschema:"description=Network policy for non-interactive sessions started with crush run"`
+}
+
+// NetworkPolicy restricts which network tools a kind of session may use.
+type NetworkPolicy struct {
+	Offline      bool     `json:"offline,omitempty" jsonschema:"description=Block all network tools in these sessions,default=false"`
+	AllowedTools []string `json:"allowed_tools,omitempty" jsonschema:"description=Network tools allowed in these sessions; all are allowed when empty,example=fetch,example=sourcegraph"`
+}
+
+// Allows reports whether the policy lets the named tool reach the network.
+func (p NetworkPolicy) Allows(toolName string) bool {
+	if p.Offline {
+		return false
+	}
+	return len(p.AllowedTools) == 0 || slices.Contains(p.AllowedTools, toolName)
+}
+
 // Config holds the configuration for crush.
 type Config struct {
 	Schema string `json:"$schema,omitempty"`
@@ -540,6 +580,7 @@ func allToolNames() []string {
 		"bash",
 		"job_output",
 		"job_kill",
+		"code_search",
 		"download",
 		"edit",
 		"multiedit",
@@ -565,7 +606,7 @@ func resolveAllowedTools(allTools []string, disabledTools []string) []string {
 }
 
 func resolveReadOnlyTools(tools []string) []string {
-	readOnlyTools := []string{"glob", "grep", "ls", "sourcegraph", "view"}
+	readOnlyTools := []string{"code_search", "glob", "grep", "ls", "sourcegraph", "view"}
 	// filter to only include tools that are in allowedtools (include mode)
 	return filterSlice(tools, readOnlyTools, true)
 }
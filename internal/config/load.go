@@ -181,17 +181,18 @@ func (c *Config) configureProviders(env env.Env, resolver VariableResolver, know
 			maps.Copy(headers, config.ExtraHeaders)
 		}
 		prepared := ProviderConfig{
-			ID:                 string(p.ID),
-			Name:               p.Name,
-			BaseURL:            p.APIEndpoint,
-			APIKey:             p.APIKey,
-			Type:               p.Type,
-			Disable:            config.Disable,
-			SystemPromptPrefix: config.SystemPromptPrefix,
-			ExtraHeaders:       headers,
-			ExtraBody:          config.ExtraBody,
-			ExtraParams:        make(map[string]string),
-			Models:             p.Models,
+			ID:                    string(p.ID),
+			Name:                  p.Name,
+			BaseURL:               p.APIEndpoint,
+			APIKey:                p.APIKey,
+			Type:                  p.Type,
+			Disable:               config.Disable,
+			SystemPromptPrefix:    config.SystemPromptPrefix,
+			ExtraHeaders:          headers,
+			ExtraBody:             config.ExtraBody,
+			ExtraParams:           make(map[string]string),
+			MaxConcurrentRequests: config.MaxConcurrentRequests,
+			Models:                p.Models,
 		}
 
 		switch p.ID {
@@ -371,6 +372,9 @@ func (c *Config) setDefaults(workingDir, dataDir string) {
 	if c.Options.InitializeAs == "" {
 		c.Options.InitializeAs = defaultInitializeAs
 	}
+	if c.Options.WorklogFile == "" {
+		c.Options.WorklogFile = defaultWorklogFile
+	}
 }
 
 // applyLSPDefaults applies default values from powernap to LSP configurations
@@ -76,6 +76,21 @@ func (w *FastGlobWalker) ShouldSkip(path string) bool {
 	return w.directoryLister.shouldIgnore(path, nil)
 }
 
+// ShouldSkipIgnored checks if a path is excluded by hierarchical gitignore,
+// crushignore or global ignore files only, without the built-in list of
+// common directories ShouldSkip also applies.
+func (w *FastGlobWalker) ShouldSkipIgnored(path string) bool {
+	dl := w.directoryLister
+	if path == dl.rootPath {
+		return false
+	}
+	relPath, err := filepath.Rel(dl.rootPath, path)
+	if err != nil {
+		relPath = path
+	}
+	return dl.ignoredByFiles(path, relPath)
+}
+
 func GlobWithDoubleStar(pattern, searchPath string, limit int) ([]string, bool, error) {
 	// Normalize pattern to forward slashes on Windows so their config can use
 	// backslashes
@@ -148,6 +148,12 @@ func (dl *directoryLister) shouldIgnore(path string, ignorePatterns []string) bo
 		return true
 	}
 
+	return dl.ignoredByFiles(path, relPath)
+}
+
+// ignoredByFiles checks path against the .gitignore and .crushignore files
+// from its directory up to dl.rootPath, and the user's global ignore files.
+func (dl *directoryLister) ignoredByFiles(path, relPath string) bool {
 	parentDir := filepath.Dir(path)
 	ignoreParser := dl.getIgnore(parentDir)
 	if ignoreParser.MatchesPath(relPath) {
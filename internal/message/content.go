@@ -32,6 +32,8 @@ const (
 	FinishReasonCanceled         FinishReason = "canceled"
 	FinishReasonError            FinishReason = "error"
 	FinishReasonPermissionDenied FinishReason = "permission_denied"
+	FinishReasonTurnLimit        FinishReason = "turn_limit"
+	FinishReasonCostLimit        FinishReason = "cost_limit"
 
 	// Should never happen
 	FinishReasonUnknown FinishReason = "unknown"
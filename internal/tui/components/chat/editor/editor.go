@@ -463,12 +463,16 @@ func (m *editorCmp) attachmentsContent() string {
 		MarginLeft(1).
 		Background(t.FgMuted).
 		Foreground(t.FgBase)
+	icon := styles.DocumentIcon
+	if styles.Accessible() {
+		icon = "file"
+	}
 	for i, attachment := range m.attachments {
 		var filename string
 		if len(attachment.FileName) > 10 {
-			filename = fmt.Sprintf(" %s %s...", styles.DocumentIcon, attachment.FileName[0:7])
+			filename = fmt.Sprintf(" %s %s...", icon, attachment.FileName[0:7])
 		} else {
-			filename = fmt.Sprintf(" %s %s", styles.DocumentIcon, attachment.FileName)
+			filename = fmt.Sprintf(" %s %s", icon, attachment.FileName)
 		}
 		if m.deleteMode {
 			filename = fmt.Sprintf("%d%s", i, filename)
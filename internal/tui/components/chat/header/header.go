@@ -116,7 +116,15 @@ func (h *header) details(availWidth int) string {
 	}
 
 	if errorCount > 0 {
-		parts = append(parts, s.Error.Render(fmt.Sprintf("%s%d", styles.ErrorIcon, errorCount)))
+		if styles.Accessible() {
+			label := fmt.Sprintf("%d errors", errorCount)
+			if errorCount == 1 {
+				label = "1 error"
+			}
+			parts = append(parts, s.Subtle.Render(label))
+		} else {
+			parts = append(parts, s.Error.Render(fmt.Sprintf("%s%d", styles.ErrorIcon, errorCount)))
+		}
 	}
 
 	agentCfg := config.Get().Agents[config.AgentCoder]
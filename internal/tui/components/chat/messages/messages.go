@@ -158,8 +158,8 @@ func (m *messageCmp) textWidth() int {
 // Applies different border colors and styles based on message role and focus state.
 func (msg *messageCmp) style() lipgloss.Style {
 	t := styles.CurrentTheme()
-	borderStyle := lipgloss.NormalBorder()
-	if msg.focused {
+	borderStyle := styles.Border()
+	if msg.focused && !styles.Accessible() {
 		borderStyle = focusedMessageBorder
 	}
 
@@ -176,6 +176,18 @@ func (msg *messageCmp) style() lipgloss.Style {
 	return style
 }
 
+// roleLabel returns the label naming the author of the message in
+// accessible mode, where the border color no longer tells them apart.
+func (m *messageCmp) roleLabel() string {
+	if !styles.Accessible() {
+		return ""
+	}
+	if m.message.Role == message.User {
+		return "You:"
+	}
+	return "Assistant:"
+}
+
 // renderAssistantMessage renders assistant messages with optional footer information.
 // Shows model name, response time, and finish reason when the message is complete.
 func (m *messageCmp) renderAssistantMessage() string {
@@ -200,9 +212,16 @@ func (m *messageCmp) renderAssistantMessage() string {
 		title := fmt.Sprintf("%s %s", errTag, t.S().Base.Foreground(t.FgHalfMuted).Render(truncated))
 		details := t.S().Base.Foreground(t.FgSubtle).Width(m.textWidth() - 2).Render(finishedData.Details)
 		errorContent := fmt.Sprintf("%s\n\n%s", title, details)
+		if label := m.roleLabel(); label != "" {
+			errorContent = label + "\n" + errorContent
+		}
 		return m.style().Render(errorContent)
 	}
 
+	if label := m.roleLabel(); label != "" {
+		parts = append(parts, label)
+	}
+
 	if thinkingContent != "" {
 		parts = append(parts, thinkingContent)
 	}
@@ -222,21 +241,27 @@ func (m *messageCmp) renderAssistantMessage() string {
 // message content and any attached files with appropriate icons.
 func (m *messageCmp) renderUserMessage() string {
 	t := styles.CurrentTheme()
-	parts := []string{
-		m.toMarkdown(m.message.Content().String()),
+	var parts []string
+	if label := m.roleLabel(); label != "" {
+		parts = append(parts, label)
 	}
+	parts = append(parts, m.toMarkdown(m.message.Content().String()))
 
 	attachmentStyles := t.S().Text.
 		MarginLeft(1).
 		Background(t.BgSubtle)
 
+	icon := styles.DocumentIcon
+	if styles.Accessible() {
+		icon = "file"
+	}
 	attachments := make([]string, len(m.message.BinaryContent()))
 	for i, attachment := range m.message.BinaryContent() {
 		const maxFilenameWidth = 10
 		filename := filepath.Base(attachment.Path)
 		attachments[i] = attachmentStyles.Render(fmt.Sprintf(
 			" %s %s ",
-			styles.DocumentIcon,
+			icon,
 			ansi.Truncate(filename, maxFilenameWidth, "..."),
 		))
 	}
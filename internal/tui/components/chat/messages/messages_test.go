@@ -0,0 +1,54 @@
+package messages
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/crush/internal/message"
+	"github.com/charmbracelet/crush/internal/tui/styles"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessageAccessible(t *testing.T) {
+	defer styles.SetAccessible(false)
+
+	user := message.Message{
+		ID:   "1",
+		Role: message.User,
+		Parts: []message.ContentPart{
+			message.TextContent{Text: "hello"},
+			message.BinaryContent{Path: "/tmp/a.png", MIMEType: "image/png", Data: []byte("png")},
+		},
+	}
+	assistant := message.Message{
+		ID:   "2",
+		Role: message.Assistant,
+		Parts: []message.ContentPart{
+			message.TextContent{Text: "hi there"},
+			message.Finish{Reason: message.FinishReasonEndTurn},
+		},
+	}
+	render := func(msg message.Message) string {
+		cmp := NewMessageCmp(msg)
+		cmp.SetSize(80, 0)
+		cmp.Focus()
+		return ansi.Strip(cmp.View())
+	}
+
+	view := render(user)
+	require.NotContains(t, view, "You:")
+	require.Contains(t, view, styles.DocumentIcon)
+	require.Contains(t, render(assistant), focusedMessageBorder.Left)
+
+	styles.SetAccessible(true)
+	view = render(user)
+	require.Contains(t, view, "You:")
+	require.Contains(t, view, "file a.png")
+	require.NotContains(t, view, styles.DocumentIcon)
+	require.NotContains(t, view, "│")
+
+	view = render(assistant)
+	require.Contains(t, view, "Assistant:")
+	require.Contains(t, view, "hi there")
+	require.NotContains(t, view, focusedMessageBorder.Left)
+}
@@ -113,19 +113,30 @@ func (br baseRenderer) unmarshalParams(input string, target any) error {
 	return json.Unmarshal([]byte(input), target)
 }
 
-// makeHeader builds the tool call header with status icon and parameters for a nested tool call.
-func (br baseRenderer) makeNestedHeader(v *toolCallCmp, tool string, width int, params ...string) string {
+// toolStatusIcon renders the status of a tool call as a colored glyph, or as
+// a word in accessible mode.
+func toolStatusIcon(v *toolCallCmp) string {
 	t := styles.CurrentTheme()
-	icon := t.S().Base.Foreground(t.GreenDark).Render(styles.ToolPending)
+	style, icon, label := t.S().Base.Foreground(t.GreenDark), styles.ToolPending, "[running]"
 	if v.result.ToolCallID != "" {
 		if v.result.IsError {
-			icon = t.S().Base.Foreground(t.RedDark).Render(styles.ToolError)
+			style, icon, label = t.S().Base.Foreground(t.RedDark), styles.ToolError, "[failed]"
 		} else {
-			icon = t.S().Base.Foreground(t.Green).Render(styles.ToolSuccess)
+			style, icon, label = t.S().Base.Foreground(t.Green), styles.ToolSuccess, "[done]"
 		}
 	} else if v.cancelled {
-		icon = t.S().Muted.Render(styles.ToolPending)
+		style, icon, label = t.S().Muted, styles.ToolPending, "[cancelled]"
+	}
+	if styles.Accessible() {
+		return style.Render(label)
 	}
+	return style.Render(icon)
+}
+
+// makeHeader builds the tool call header with status icon and parameters for a nested tool call.
+func (br baseRenderer) makeNestedHeader(v *toolCallCmp, tool string, width int, params ...string) string {
+	t := styles.CurrentTheme()
+	icon := toolStatusIcon(v)
 	tool = t.S().Base.Foreground(t.FgHalfMuted).Render(tool)
 	prefix := fmt.Sprintf("%s %s ", icon, tool)
 	return prefix + renderParamList(true, width-lipgloss.Width(prefix), params...)
@@ -137,16 +148,7 @@ func (br baseRenderer) makeHeader(v *toolCallCmp, tool string, width int, params
 		return br.makeNestedHeader(v, tool, width, params...)
 	}
 	t := styles.CurrentTheme()
-	icon := t.S().Base.Foreground(t.GreenDark).Render(styles.ToolPending)
-	if v.result.ToolCallID != "" {
-		if v.result.IsError {
-			icon = t.S().Base.Foreground(t.RedDark).Render(styles.ToolError)
-		} else {
-			icon = t.S().Base.Foreground(t.Green).Render(styles.ToolSuccess)
-		}
-	} else if v.cancelled {
-		icon = t.S().Muted.Render(styles.ToolPending)
-	}
+	icon := toolStatusIcon(v)
 	tool = t.S().Base.Foreground(t.Blue).Render(tool)
 	prefix := fmt.Sprintf("%s %s ", icon, tool)
 	return prefix + renderParamList(false, width-lipgloss.Width(prefix), params...)
@@ -166,6 +168,7 @@ func init() {
 	registry.register(tools.BashToolName, func() renderer { return bashRenderer{} })
 	registry.register(tools.JobOutputToolName, func() renderer { return bashOutputRenderer{} })
 	registry.register(tools.JobKillToolName, func() renderer { return bashKillRenderer{} })
+	registry.register(tools.CodeSearchToolName, func() renderer { return codeSearchRenderer{} })
 	registry.register(tools.DownloadToolName, func() renderer { return downloadRenderer{} })
 	registry.register(tools.ViewToolName, func() renderer { return viewRenderer{} })
 	registry.register(tools.EditToolName, func() renderer { return editRenderer{} })
@@ -265,16 +268,7 @@ func (br bashRenderer) Render(v *toolCallCmp) string {
 
 func makeJobHeader(v *toolCallCmp, subcommand, pid, description string, width int) string {
 	t := styles.CurrentTheme()
-	icon := t.S().Base.Foreground(t.GreenDark).Render(styles.ToolPending)
-	if v.result.ToolCallID != "" {
-		if v.result.IsError {
-			icon = t.S().Base.Foreground(t.RedDark).Render(styles.ToolError)
-		} else {
-			icon = t.S().Base.Foreground(t.Green).Render(styles.ToolSuccess)
-		}
-	} else if v.cancelled {
-		icon = t.S().Muted.Render(styles.ToolPending)
-	}
+	icon := toolStatusIcon(v)
 
 	jobPart := t.S().Base.Foreground(t.Blue).Render("Job")
 	subcommandPart := t.S().Base.Foreground(t.BlueDark).Render("(" + subcommand + ")")
@@ -778,6 +772,34 @@ func (gr grepRenderer) Render(v *toolCallCmp) string {
 	})
 }
 
+// -----------------------------------------------------------------------------
+//  Code search renderer
+// -----------------------------------------------------------------------------
+
+// codeSearchRenderer handles language-aware code searches
+type codeSearchRenderer struct {
+	baseRenderer
+}
+
+// Render displays the query with path, include, literal and definitions options
+func (cr codeSearchRenderer) Render(v *toolCallCmp) string {
+	var params tools.CodeSearchParams
+	var args []string
+	if err := cr.unmarshalParams(v.call.Input, &params); err == nil {
+		args = newParamBuilder().
+			addMain(params.Query).
+			addKeyValue("path", params.Path).
+			addKeyValue("include", params.Include).
+			addFlag("literal", params.LiteralText).
+			addFlag("definitions", params.DefinitionsOnly).
+			build()
+	}
+
+	return cr.renderWithParams(v, "Code Search", args, func() string {
+		return renderPlainContent(v, v.result.Content)
+	})
+}
+
 // -----------------------------------------------------------------------------
 //  LS renderer
 // -----------------------------------------------------------------------------
@@ -1145,8 +1167,13 @@ func renderCodeContent(v *toolCallCmp, path, content string, offset int) string
 
 func (v *toolCallCmp) renderToolError() string {
 	t := styles.CurrentTheme()
-	err := strings.ReplaceAll(v.result.Content, "\n", " ")
-	errTag := t.S().Base.Padding(0, 1).Background(t.Red).Foreground(t.White).Render("ERROR")
+	kind := tools.ErrorKindFromMetadata(v.result.Metadata)
+	err := strings.ReplaceAll(tools.TrimErrorKind(kind, v.result.Content), "\n", " ")
+	label := "ERROR"
+	if kind != "" {
+		label = strings.ToUpper(strings.ReplaceAll(string(kind), "_", " "))
+	}
+	errTag := t.S().Base.Padding(0, 1).Background(t.Red).Foreground(t.White).Render(label)
 	err = fmt.Sprintf("%s %s", errTag, t.S().Base.Foreground(t.FgHalfMuted).Render(v.fit(err, v.textWidth()-2-lipgloss.Width(errTag))))
 	return err
 }
@@ -1169,6 +1196,8 @@ func prettifyToolName(name string) string {
 		return "Job: Output"
 	case tools.JobKillToolName:
 		return "Job: Kill"
+	case tools.CodeSearchToolName:
+		return "Code Search"
 	case tools.DownloadToolName:
 		return "Download"
 	case tools.EditToolName:
@@ -0,0 +1,29 @@
+package messages
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/crush/internal/message"
+	"github.com/charmbracelet/crush/internal/tui/styles"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToolStatusIcon(t *testing.T) {
+	defer styles.SetAccessible(false)
+
+	running := &toolCallCmp{}
+	cancelled := &toolCallCmp{cancelled: true}
+	done := &toolCallCmp{result: message.ToolResult{ToolCallID: "1"}}
+	failed := &toolCallCmp{result: message.ToolResult{ToolCallID: "1", IsError: true}}
+
+	require.Equal(t, styles.ToolPending, ansi.Strip(toolStatusIcon(running)))
+	require.Equal(t, styles.ToolSuccess, ansi.Strip(toolStatusIcon(done)))
+	require.Equal(t, styles.ToolError, ansi.Strip(toolStatusIcon(failed)))
+
+	styles.SetAccessible(true)
+	require.Equal(t, "[running]", ansi.Strip(toolStatusIcon(running)))
+	require.Equal(t, "[cancelled]", ansi.Strip(toolStatusIcon(cancelled)))
+	require.Equal(t, "[done]", ansi.Strip(toolStatusIcon(done)))
+	require.Equal(t, "[failed]", ansi.Strip(toolStatusIcon(failed)))
+}
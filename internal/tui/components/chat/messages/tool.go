@@ -224,8 +224,13 @@ func (m *toolCallCmp) formatToolForCopy() string {
 
 	if m.result.ToolCallID != "" {
 		if m.result.IsError {
-			parts = append(parts, "### Error:")
-			parts = append(parts, m.result.Content)
+			kind := tools.ErrorKindFromMetadata(m.result.Metadata)
+			if kind != "" {
+				parts = append(parts, fmt.Sprintf("### Error (%s):", kind))
+			} else {
+				parts = append(parts, "### Error:")
+			}
+			parts = append(parts, tools.TrimErrorKind(kind, m.result.Content))
 		} else {
 			parts = append(parts, "### Result:")
 			content := m.formatResultForCopy()
@@ -312,6 +317,25 @@ func (m *toolCallCmp) formatParametersForCopy() string {
 		if json.Unmarshal([]byte(m.call.Input), &params) == nil {
 			return fmt.Sprintf("**URL:** %s", params.URL)
 		}
+	case tools.CodeSearchToolName:
+		var params tools.CodeSearchParams
+		if json.Unmarshal([]byte(m.call.Input), &params) == nil {
+			var parts []string
+			parts = append(parts, fmt.Sprintf("**Query:** %s", params.Query))
+			if params.Path != "" {
+				parts = append(parts, fmt.Sprintf("**Path:** %s", params.Path))
+			}
+			if params.Include != "" {
+				parts = append(parts, fmt.Sprintf("**Include:** %s", params.Include))
+			}
+			if params.LiteralText {
+				parts = append(parts, "**Literal:** true")
+			}
+			if params.DefinitionsOnly {
+				parts = append(parts, "**Definitions only:** true")
+			}
+			return strings.Join(parts, "\n")
+		}
 	case tools.GrepToolName:
 		var params tools.GrepParams
 		if json.Unmarshal([]byte(m.call.Input), &params) == nil {
@@ -416,7 +440,7 @@ func (m *toolCallCmp) formatResultForCopy() string {
 		return m.formatWebFetchResultForCopy()
 	case agent.AgentToolName:
 		return m.formatAgentResultForCopy()
-	case tools.DownloadToolName, tools.GrepToolName, tools.GlobToolName, tools.LSToolName, tools.SourcegraphToolName, tools.DiagnosticsToolName:
+	case tools.CodeSearchToolName, tools.DownloadToolName, tools.GrepToolName, tools.GlobToolName, tools.LSToolName, tools.SourcegraphToolName, tools.DiagnosticsToolName:
 		return fmt.Sprintf("```\n%s\n```", m.result.Content)
 	default:
 		return m.result.Content
@@ -754,12 +778,15 @@ func (m *toolCallCmp) SetIsNested(isNested bool) {
 // renderPending displays the tool name with a loading animation for pending tool calls
 func (m *toolCallCmp) renderPending() string {
 	t := styles.CurrentTheme()
-	icon := t.S().Base.Foreground(t.GreenDark).Render(styles.ToolPending)
+	icon := toolStatusIcon(m)
+	tool := t.S().Base.Foreground(t.Blue).Render(prettifyToolName(m.call.Name))
 	if m.isNested {
-		tool := t.S().Base.Foreground(t.FgHalfMuted).Render(prettifyToolName(m.call.Name))
-		return fmt.Sprintf("%s %s %s", icon, tool, m.anim.View())
+		tool = t.S().Base.Foreground(t.FgHalfMuted).Render(prettifyToolName(m.call.Name))
+	}
+	if styles.Accessible() {
+		// The animation redraws constantly, which screen readers announce.
+		return fmt.Sprintf("%s %s", icon, tool)
 	}
-	tool := t.S().Base.Foreground(t.Blue).Render(prettifyToolName(m.call.Name))
 	return fmt.Sprintf("%s %s %s", icon, tool, m.anim.View())
 }
 
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"strings"
 
-	"charm.land/lipgloss/v2"
 	"github.com/charmbracelet/crush/internal/tui/styles"
 )
 
@@ -12,17 +11,20 @@ func queuePill(queue int, t *styles.Theme) string {
 	if queue <= 0 {
 		return ""
 	}
-	triangles := styles.ForegroundGrad("▶▶▶▶▶▶▶▶▶", false, t.RedDark, t.Accent)
-	if queue < 10 {
-		triangles = triangles[:queue]
-	}
 
-	allTriangles := strings.Join(triangles, "")
+	label := fmt.Sprintf("Queued prompts: %d", queue)
+	if !styles.Accessible() {
+		triangles := styles.ForegroundGrad("▶▶▶▶▶▶▶▶▶", false, t.RedDark, t.Accent)
+		if queue < 10 {
+			triangles = triangles[:queue]
+		}
+		label = fmt.Sprintf("%s %d Queued", strings.Join(triangles, ""), queue)
+	}
 
 	return t.S().Base.
-		BorderStyle(lipgloss.RoundedBorder()).
+		BorderStyle(styles.Border()).
 		BorderForeground(t.BgOverlay).
 		PaddingLeft(1).
 		PaddingRight(1).
-		Render(fmt.Sprintf("%s %d Queued", allTriangles, queue))
+		Render(label)
 }
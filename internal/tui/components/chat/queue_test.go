@@ -0,0 +1,23 @@
+package chat
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/crush/internal/tui/styles"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueuePill(t *testing.T) {
+	defer styles.SetAccessible(false)
+	th := styles.CurrentTheme()
+
+	require.Empty(t, queuePill(0, th))
+	require.Contains(t, ansi.Strip(queuePill(3, th)), "▶▶▶ 3 Queued")
+
+	styles.SetAccessible(true)
+	pill := strings.TrimSpace(ansi.Strip(queuePill(3, th)))
+	require.Equal(t, "Queued prompts: 3", pill)
+	require.NotContains(t, pill, "▶")
+}
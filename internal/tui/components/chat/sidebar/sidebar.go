@@ -537,7 +537,11 @@ func formatTokensAndCost(tokens, contextWindow int64, cost float64) string {
 	formattedTokens = fmt.Sprintf("%s %s", formattedPercentage, formattedTokens)
 	if percentage > 80 {
 		// add the warning icon
-		formattedTokens = fmt.Sprintf("%s %s", styles.WarningIcon, formattedTokens)
+		warning := styles.WarningIcon
+		if styles.Accessible() {
+			warning = "context almost full:"
+		}
+		formattedTokens = fmt.Sprintf("%s %s", warning, formattedTokens)
 	}
 
 	return fmt.Sprintf("%s %s", formattedTokens, formattedCost)
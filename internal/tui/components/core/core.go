@@ -82,7 +82,7 @@ func Title(title string, width int) string {
 }
 
 type StatusOpts struct {
-	Icon             string // if empty no icon will be shown
+	Icon             string // if empty or in accessible mode no icon will be shown
 	Title            string
 	TitleColor       color.Color
 	Description      string
@@ -93,6 +93,9 @@ type StatusOpts struct {
 func Status(opts StatusOpts, width int) string {
 	t := styles.CurrentTheme()
 	icon := opts.Icon
+	if styles.Accessible() {
+		icon = ""
+	}
 	title := opts.Title
 	titleColor := t.FgMuted
 	if opts.TitleColor != nil {
@@ -86,6 +86,9 @@ type (
 	CompactMsg             struct {
 		SessionID string
 	}
+	InspectPromptTokensMsg struct {
+		SessionID string
+	}
 )
 
 func NewCommandDialog(sessionID string) CommandsDialog {
@@ -301,7 +304,7 @@ func (c *commandDialogCmp) style() lipgloss.Style {
 	t := styles.CurrentTheme()
 	return t.S().Base.
 		Width(c.width).
-		Border(lipgloss.RoundedBorder()).
+		Border(styles.Border()).
 		BorderForeground(t.BorderFocus)
 }
 
@@ -354,6 +357,15 @@ func (c *commandDialogCmp) defaultCommands() []Command {
 					SessionID: c.sessionID,
 				})
 			},
+		}, Command{
+			ID:          "inspect_prompt_tokens",
+			Title:       "Inspect Prompt Tokens",
+			Description: "Show how the prompt of the current session is composed, with token counts",
+			Handler: func(cmd Command) tea.Cmd {
+				return util.CmdHandler(InspectPromptTokensMsg{
+					SessionID: c.sessionID,
+				})
+			},
 		})
 	}
 
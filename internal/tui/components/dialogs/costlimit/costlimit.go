@@ -0,0 +1,145 @@
+package costlimit
+
+import (
+	"fmt"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/crush/internal/message"
+	"github.com/charmbracelet/crush/internal/tui/components/dialogs"
+	"github.com/charmbracelet/crush/internal/tui/styles"
+	"github.com/charmbracelet/crush/internal/tui/util"
+)
+
+const CostLimitDialogID dialogs.DialogID = "cost_limit"
+
+// RaiseLimitMsg is sent when the user agrees to raise the cost limit of a
+// session. Prompt is sent once the limit is raised: either the prompt that
+// was refused, or one resuming the request that was paused.
+type RaiseLimitMsg struct {
+	SessionID   string
+	Limit       float64
+	Prompt      string
+	Attachments []message.Attachment
+}
+
+// CostLimitDialog asks whether to raise the cost limit of a paused session.
+type CostLimitDialog interface {
+	dialogs.DialogModel
+}
+
+type costLimitDialogCmp struct {
+	wWidth  int
+	wHeight int
+
+	question   string
+	raiseMsg   RaiseLimitMsg
+	selectedNo bool
+	keymap     KeyMap
+}
+
+// NewCostLimitDialog creates a dialog offering to raise the limit of a session
+// from limit to newLimit. If the user agrees, prompt is sent.
+func NewCostLimitDialog(sessionID string, cost, limit, newLimit float64, prompt string, attachments []message.Attachment) CostLimitDialog {
+	return &costLimitDialogCmp{
+		question: fmt.Sprintf("This session has cost $%.2f, reaching its $%.2f limit.\nRaise the limit to $%.2f and continue?", cost, limit, newLimit),
+		raiseMsg: RaiseLimitMsg{
+			SessionID:   sessionID,
+			Limit:       newLimit,
+			Prompt:      prompt,
+			Attachments: attachments,
+		},
+		selectedNo: true,
+		keymap:     DefaultKeymap(),
+	}
+}
+
+func (c *costLimitDialogCmp) Init() tea.Cmd {
+	return nil
+}
+
+func (c *costLimitDialogCmp) Update(msg tea.Msg) (util.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		c.wWidth = msg.Width
+		c.wHeight = msg.Height
+	case tea.KeyPressMsg:
+		switch {
+		case key.Matches(msg, c.keymap.LeftRight, c.keymap.Tab):
+			c.selectedNo = !c.selectedNo
+			return c, nil
+		case key.Matches(msg, c.keymap.EnterSpace):
+			if !c.selectedNo {
+				return c, c.raise()
+			}
+			return c, util.CmdHandler(dialogs.CloseDialogMsg{})
+		case key.Matches(msg, c.keymap.Yes):
+			return c, c.raise()
+		case key.Matches(msg, c.keymap.No, c.keymap.Close):
+			return c, util.CmdHandler(dialogs.CloseDialogMsg{})
+		}
+	}
+	return c, nil
+}
+
+func (c *costLimitDialogCmp) raise() tea.Cmd {
+	return tea.Sequence(
+		util.CmdHandler(dialogs.CloseDialogMsg{}),
+		util.CmdHandler(c.raiseMsg),
+	)
+}
+
+func (c *costLimitDialogCmp) View() string {
+	t := styles.CurrentTheme()
+	baseStyle := t.S().Base
+	yesStyle := t.S().Text
+	noStyle := yesStyle
+
+	if c.selectedNo {
+		noStyle = noStyle.Foreground(t.White).Background(t.Secondary)
+		yesStyle = yesStyle.Background(t.BgSubtle)
+	} else {
+		yesStyle = yesStyle.Foreground(t.White).Background(t.Secondary)
+		noStyle = noStyle.Background(t.BgSubtle)
+	}
+
+	const horizontalPadding = 3
+	yesButton := yesStyle.PaddingLeft(horizontalPadding).Underline(true).Render("Y") +
+		yesStyle.PaddingRight(horizontalPadding).Render("es")
+	noButton := noStyle.PaddingLeft(horizontalPadding).Underline(true).Render("N") +
+		noStyle.PaddingRight(horizontalPadding).Render("o")
+
+	buttons := baseStyle.Width(lipgloss.Width(c.question)).Align(lipgloss.Right).Render(
+		lipgloss.JoinHorizontal(lipgloss.Center, yesButton, "  ", noButton),
+	)
+
+	content := baseStyle.Render(
+		lipgloss.JoinVertical(
+			lipgloss.Center,
+			c.question,
+			"",
+			buttons,
+		),
+	)
+
+	dialogStyle := baseStyle.
+		Padding(1, 2).
+		Border(styles.Border()).
+		BorderForeground(t.BorderFocus)
+
+	return dialogStyle.Render(content)
+}
+
+func (c *costLimitDialogCmp) Position() (int, int) {
+	row := c.wHeight / 2
+	row -= 8 / 2
+	col := c.wWidth / 2
+	col -= (lipgloss.Width(c.question) + 6) / 2
+
+	return row, col
+}
+
+func (c *costLimitDialogCmp) ID() dialogs.DialogID {
+	return CostLimitDialogID
+}
@@ -226,7 +226,7 @@ func (m *model) style() lipgloss.Style {
 	t := styles.CurrentTheme()
 	return t.S().Base.
 		Width(m.width).
-		Border(lipgloss.RoundedBorder()).
+		Border(styles.Border()).
 		BorderForeground(t.BorderFocus)
 }
 
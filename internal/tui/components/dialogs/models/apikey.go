@@ -130,6 +130,9 @@ func (a *APIKeyInput) updateStatePresentation() {
 		ts.Blurred.Prompt = ts.Focused.Prompt
 		a.input.SetStyles(ts)
 		a.input.Prompt = styles.CheckIcon + " "
+		if styles.Accessible() {
+			a.input.Prompt = "[valid] "
+		}
 		a.input.Blur()
 	case APIKeyInputStateError:
 		a.title = errorStyle.Render("Invalid ") + accentStyle.Render(a.providerName+" API Key") + errorStyle.Render(". Try again?")
@@ -138,6 +141,9 @@ func (a *APIKeyInput) updateStatePresentation() {
 		a.input.Focus()
 		a.input.SetStyles(ts)
 		a.input.Prompt = styles.ErrorIcon + " "
+		if styles.Accessible() {
+			a.input.Prompt = "[invalid] "
+		}
 	}
 }
 
@@ -0,0 +1,22 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/crush/internal/tui/styles"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIKeyInputPrompt(t *testing.T) {
+	defer styles.SetAccessible(false)
+
+	a := NewAPIKeyInput()
+	a.Update(APIKeyStateChangeMsg{State: APIKeyInputStateVerified})
+	require.Equal(t, styles.CheckIcon+" ", a.input.Prompt)
+
+	styles.SetAccessible(true)
+	a.Update(APIKeyStateChangeMsg{State: APIKeyInputStateVerified})
+	require.Equal(t, "[valid] ", a.input.Prompt)
+	a.Update(APIKeyStateChangeMsg{State: APIKeyInputStateError})
+	require.Equal(t, "[invalid] ", a.input.Prompt)
+}
@@ -127,6 +127,9 @@ func (m *ModelListComponent) SetModelType(modelType int) tea.Cmd {
 
 	configuredIcon := t.S().Base.Foreground(t.Success).Render(styles.CheckIcon)
 	configured := fmt.Sprintf("%s %s", configuredIcon, t.S().Subtle.Render("Configured"))
+	if styles.Accessible() {
+		configured = t.S().Subtle.Render("Configured")
+	}
 
 	// Create a map to track which providers we've already added
 	addedProviders := make(map[string]bool)
@@ -295,7 +295,7 @@ func (m *modelDialogCmp) style() lipgloss.Style {
 	t := styles.CurrentTheme()
 	return t.S().Base.
 		Width(m.width).
-		Border(lipgloss.RoundedBorder()).
+		Border(styles.Border()).
 		BorderForeground(t.BorderFocus)
 }
 
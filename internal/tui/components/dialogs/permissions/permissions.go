@@ -781,7 +781,7 @@ func (p *permissionDialogCmp) render() string {
 
 	dialog := baseStyle.
 		Padding(0, 1).
-		Border(lipgloss.RoundedBorder()).
+		Border(styles.Border()).
 		BorderForeground(t.BorderFocus).
 		Width(p.width).
 		Render(
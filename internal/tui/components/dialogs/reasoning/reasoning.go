@@ -248,7 +248,7 @@ func (r *reasoningDialogCmp) style() lipgloss.Style {
 	t := styles.CurrentTheme()
 	return t.S().Base.
 		Width(r.width).
-		Border(lipgloss.RoundedBorder()).
+		Border(styles.Border()).
 		BorderForeground(t.BorderFocus)
 }
 
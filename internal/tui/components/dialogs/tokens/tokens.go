@@ -0,0 +1,168 @@
+package tokens
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"charm.land/bubbles/v2/help"
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/crush/internal/agent"
+	"github.com/charmbracelet/crush/internal/tui/components/core"
+	"github.com/charmbracelet/crush/internal/tui/components/dialogs"
+	"github.com/charmbracelet/crush/internal/tui/styles"
+	"github.com/charmbracelet/crush/internal/tui/util"
+	"github.com/charmbracelet/x/ansi"
+)
+
+const (
+	TokensDialogID dialogs.DialogID = "tokens"
+
+	defaultWidth int = 60
+)
+
+// TokensDialog shows how the prompt of a session is composed.
+type TokensDialog interface {
+	dialogs.DialogModel
+}
+
+type row struct {
+	name   string
+	tokens int64
+	header bool
+	// estimate marks counts that are rougher than the rest, shown with a
+	// leading tilde.
+	estimate bool
+}
+
+type tokensDialogCmp struct {
+	wWidth  int
+	wHeight int
+
+	rows   []row
+	offset int
+	keymap KeyMap
+	help   help.Model
+}
+
+// NewTokensDialog creates a dialog showing the given prompt breakdown.
+func NewTokensDialog(breakdown agent.PromptBreakdown) TokensDialog {
+	t := styles.CurrentTheme()
+	h := help.New()
+	h.Styles = t.S().Help
+	return &tokensDialogCmp{
+		rows:   breakdownRows(breakdown),
+		keymap: DefaultKeymap(),
+		help:   h,
+	}
+}
+
+func breakdownRows(b agent.PromptBreakdown) []row {
+	rows := []row{
+		{name: "System", tokens: b.TotalFor(agent.PromptComponentSystem)},
+		{name: "Summary", tokens: b.TotalFor(agent.PromptComponentSummary)},
+		{name: "Messages", tokens: b.TotalFor(agent.PromptComponentMessage)},
+		{name: "Tools", tokens: b.TotalFor(agent.PromptComponentTool)},
+		{name: "Attachments (rough estimate)", tokens: b.TotalFor(agent.PromptComponentAttachment), estimate: true},
+		{name: "Estimated total", tokens: b.Total(), header: true},
+		{name: "Last reported prompt", tokens: b.ReportedTokens},
+	}
+	if b.ContextWindow > 0 {
+		rows = append(rows, row{name: "Context window", tokens: b.ContextWindow})
+	}
+	rows = append(rows, row{name: "Components", header: true, tokens: -1})
+	for _, c := range b.Components {
+		rows = append(rows, row{
+			name:     fmt.Sprintf("%s (%s)", c.Name, c.Kind),
+			tokens:   c.Tokens,
+			estimate: c.Kind == agent.PromptComponentAttachment,
+		})
+	}
+	return rows
+}
+
+func (d *tokensDialogCmp) Init() tea.Cmd {
+	return nil
+}
+
+func (d *tokensDialogCmp) Update(msg tea.Msg) (util.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		d.wWidth = msg.Width
+		d.wHeight = msg.Height
+		d.offset = min(d.offset, d.maxOffset())
+	case tea.KeyPressMsg:
+		switch {
+		case key.Matches(msg, d.keymap.Next):
+			d.offset = min(d.offset+1, d.maxOffset())
+		case key.Matches(msg, d.keymap.Previous):
+			d.offset = max(d.offset-1, 0)
+		case key.Matches(msg, d.keymap.Close):
+			return d, util.CmdHandler(dialogs.CloseDialogMsg{})
+		}
+	}
+	return d, nil
+}
+
+func (d *tokensDialogCmp) View() string {
+	t := styles.CurrentTheme()
+	width := d.width()
+	nameWidth := width - 4 - 10
+
+	var lines []string
+	end := min(d.offset+d.listHeight(), len(d.rows))
+	for _, r := range d.rows[d.offset:end] {
+		name := ansi.Truncate(r.name, nameWidth, "…")
+		style := t.S().Text
+		if r.header {
+			style = t.S().Subtle.Bold(true)
+		}
+		var tokens string
+		if r.tokens >= 0 {
+			tokens = strconv.FormatInt(r.tokens, 10)
+			if r.estimate {
+				tokens = "~" + tokens
+			}
+		}
+		lines = append(lines, style.Width(nameWidth).Render(name)+style.Width(10).Align(lipgloss.Right).Render(tokens))
+	}
+
+	header := t.S().Base.Padding(0, 1, 1, 1).Render(core.Title("Prompt Tokens", width-4))
+	content := lipgloss.JoinVertical(
+		lipgloss.Left,
+		header,
+		t.S().Base.PaddingLeft(1).Render(strings.Join(lines, "\n")),
+		"",
+		t.S().Base.Width(width-2).PaddingLeft(1).Render(d.help.View(d.keymap)),
+	)
+	return t.S().Base.
+		Width(width).
+		Border(styles.Border()).
+		BorderForeground(t.BorderFocus).
+		Render(content)
+}
+
+func (d *tokensDialogCmp) width() int {
+	return min(defaultWidth, d.wWidth-4)
+}
+
+func (d *tokensDialogCmp) listHeight() int {
+	return max(min(len(d.rows), d.wHeight/2), 1)
+}
+
+func (d *tokensDialogCmp) maxOffset() int {
+	return max(len(d.rows)-d.listHeight(), 0)
+}
+
+func (d *tokensDialogCmp) Position() (int, int) {
+	row := d.wHeight/4 - 2 // just a bit above the center
+	col := d.wWidth / 2
+	col -= d.width() / 2
+	return row, col
+}
+
+func (d *tokensDialogCmp) ID() dialogs.DialogID {
+	return TokensDialogID
+}
@@ -2,6 +2,7 @@ package lsp
 
 import (
 	"fmt"
+	"image/color"
 	"strings"
 
 	"charm.land/lipgloss/v2"
@@ -77,20 +78,7 @@ func RenderLSPList(lspClients *csync.Map[string, *lsp.Client], opts RenderOption
 				}
 			}
 
-			errs := []string{}
-			if lspErrs[protocol.SeverityError] > 0 {
-				errs = append(errs, t.S().Base.Foreground(t.Error).Render(fmt.Sprintf("%s %d", styles.ErrorIcon, lspErrs[protocol.SeverityError])))
-			}
-			if lspErrs[protocol.SeverityWarning] > 0 {
-				errs = append(errs, t.S().Base.Foreground(t.Warning).Render(fmt.Sprintf("%s %d", styles.WarningIcon, lspErrs[protocol.SeverityWarning])))
-			}
-			if lspErrs[protocol.SeverityHint] > 0 {
-				errs = append(errs, t.S().Base.Foreground(t.FgHalfMuted).Render(fmt.Sprintf("%s %d", styles.HintIcon, lspErrs[protocol.SeverityHint])))
-			}
-			if lspErrs[protocol.SeverityInformation] > 0 {
-				errs = append(errs, t.S().Base.Foreground(t.FgHalfMuted).Render(fmt.Sprintf("%s %d", styles.InfoIcon, lspErrs[protocol.SeverityInformation])))
-			}
-			extraContent = strings.Join(errs, " ")
+			extraContent = diagnosticCounts(t, lspErrs)
 		}
 
 		lspList = append(lspList,
@@ -119,7 +107,7 @@ func iconAndDescription(l config.LSP, t *styles.Theme, states map[string]app.LSP
 	case lsp.StateStarting:
 		return t.ItemBusyIcon, t.S().Subtle.Render("starting...")
 	case lsp.StateReady:
-		return t.ItemOnlineIcon, ""
+		return t.ItemOnlineIcon, accessibleState(t, "ready")
 	case lsp.StateError:
 		description := t.S().Subtle.Render("error")
 		if info.Error != nil {
@@ -129,8 +117,54 @@ func iconAndDescription(l config.LSP, t *styles.Theme, states map[string]app.LSP
 	case lsp.StateDisabled:
 		return t.ItemOfflineIcon.Foreground(t.FgMuted), t.S().Subtle.Render("inactive")
 	default:
-		return t.ItemOfflineIcon, ""
+		return t.ItemOfflineIcon, accessibleState(t, "offline")
+	}
+}
+
+// diagnosticCounts renders the number of diagnostics of each severity as
+// colored glyphs, or as words in accessible mode.
+func diagnosticCounts(t *styles.Theme, counts map[protocol.DiagnosticSeverity]int) string {
+	severities := []struct {
+		severity protocol.DiagnosticSeverity
+		icon     string
+		color    color.Color
+		word     string
+	}{
+		{protocol.SeverityError, styles.ErrorIcon, t.Error, "error"},
+		{protocol.SeverityWarning, styles.WarningIcon, t.Warning, "warning"},
+		{protocol.SeverityHint, styles.HintIcon, t.FgHalfMuted, "hint"},
+		{protocol.SeverityInformation, styles.InfoIcon, t.FgHalfMuted, "info"},
+	}
+
+	var parts []string
+	for _, s := range severities {
+		count := counts[s.severity]
+		if count == 0 {
+			continue
+		}
+		if styles.Accessible() {
+			word := s.word
+			if count != 1 && s.severity != protocol.SeverityInformation {
+				word += "s"
+			}
+			parts = append(parts, t.S().Subtle.Render(fmt.Sprintf("%d %s", count, word)))
+			continue
+		}
+		parts = append(parts, t.S().Base.Foreground(s.color).Render(fmt.Sprintf("%s %d", s.icon, count)))
+	}
+	if styles.Accessible() {
+		return strings.Join(parts, ", ")
+	}
+	return strings.Join(parts, " ")
+}
+
+// accessibleState spells out a state that is otherwise only shown by the
+// color of its icon, as icons are hidden in accessible mode.
+func accessibleState(t *styles.Theme, state string) string {
+	if !styles.Accessible() {
+		return ""
 	}
+	return t.S().Subtle.Render(state)
 }
 
 // RenderLSPBlock renders a complete LSP block with optional truncation indicator.
@@ -0,0 +1,27 @@
+package lsp
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/crush/internal/tui/styles"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/charmbracelet/x/powernap/pkg/lsp/protocol"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiagnosticCounts(t *testing.T) {
+	defer styles.SetAccessible(false)
+	th := styles.CurrentTheme()
+
+	counts := map[protocol.DiagnosticSeverity]int{
+		protocol.SeverityError:       3,
+		protocol.SeverityWarning:     1,
+		protocol.SeverityHint:        0,
+		protocol.SeverityInformation: 2,
+	}
+	require.Equal(t, styles.ErrorIcon+" 3 "+styles.WarningIcon+" 1 "+styles.InfoIcon+" 2", ansi.Strip(diagnosticCounts(th, counts)))
+
+	styles.SetAccessible(true)
+	require.Equal(t, "3 errors, 1 warning, 2 info", ansi.Strip(diagnosticCounts(th, counts)))
+	require.Empty(t, diagnosticCounts(th, map[protocol.DiagnosticSeverity]int{}))
+}
@@ -68,6 +68,10 @@ func RenderMCPList(opts RenderOptions) []string {
 				description = t.S().Subtle.Render("starting...")
 			case mcp.StateConnected:
 				icon = t.ItemOnlineIcon
+				if styles.Accessible() {
+					// Icons are hidden in accessible mode, so spell out the state.
+					description = t.S().Subtle.Render("connected")
+				}
 				if count := state.Counts.Tools; count > 0 {
 					extraContent = append(extraContent, t.S().Subtle.Render(fmt.Sprintf("%d tools", count)))
 				}
@@ -84,6 +88,8 @@ func RenderMCPList(opts RenderOptions) []string {
 			}
 		} else if l.MCP.Disabled {
 			description = t.S().Subtle.Render("disabled")
+		} else if styles.Accessible() {
+			description = t.S().Subtle.Render("offline")
 		}
 
 		mcpList = append(mcpList,
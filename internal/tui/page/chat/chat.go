@@ -1,9 +1,11 @@
 package chat
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"time"
 
 	"charm.land/bubbles/v2/help"
@@ -11,6 +13,7 @@ import (
 	"charm.land/bubbles/v2/spinner"
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/crush/internal/agent"
 	"github.com/charmbracelet/crush/internal/app"
 	"github.com/charmbracelet/crush/internal/config"
 	"github.com/charmbracelet/crush/internal/history"
@@ -30,6 +33,7 @@ import (
 	"github.com/charmbracelet/crush/internal/tui/components/core/layout"
 	"github.com/charmbracelet/crush/internal/tui/components/dialogs"
 	"github.com/charmbracelet/crush/internal/tui/components/dialogs/commands"
+	"github.com/charmbracelet/crush/internal/tui/components/dialogs/costlimit"
 	"github.com/charmbracelet/crush/internal/tui/components/dialogs/filepicker"
 	"github.com/charmbracelet/crush/internal/tui/components/dialogs/models"
 	"github.com/charmbracelet/crush/internal/tui/components/dialogs/reasoning"
@@ -260,6 +264,9 @@ func (p *chatPage) Update(msg tea.Msg) (util.Model, tea.Cmd) {
 		return p, p.toggleThinking()
 	case commands.OpenReasoningDialogMsg:
 		return p, p.openReasoningDialog()
+	case costlimit.RaiseLimitMsg:
+		p.app.AgentCoordinator.SetSessionCostLimit(msg.SessionID, msg.Limit)
+		return p, p.sendMessage(msg.Prompt, msg.Attachments)
 	case reasoning.ReasoningEffortSelectedMsg:
 		return p, p.handleReasoningEffortSelected(msg.Effort)
 	case commands.OpenExternalEditorMsg:
@@ -267,6 +274,12 @@ func (p *chatPage) Update(msg tea.Msg) (util.Model, tea.Cmd) {
 		p.editor = u.(editor.Editor)
 		return p, cmd
 	case pubsub.Event[session.Session]:
+		if msg.Payload.ID == p.session.ID {
+			if warning := p.app.Config().Options.SessionCostWarning; warning > 0 && p.session.Cost < warning && msg.Payload.Cost >= warning {
+				cmds = append(cmds, util.ReportWarn(fmt.Sprintf("Session cost is $%.2f, above the $%.2f warning threshold", msg.Payload.Cost, warning)))
+			}
+			p.session = msg.Payload
+		}
 		u, cmd := p.header.Update(msg)
 		p.header = u.(header.Header)
 		cmds = append(cmds, cmd)
@@ -303,6 +316,13 @@ func (p *chatPage) Update(msg tea.Msg) (util.Model, tea.Cmd) {
 	case pubsub.Event[message.Message],
 		anim.StepMsg,
 		spinner.TickMsg:
+		if ev, ok := msg.(pubsub.Event[message.Message]); ok && ev.Type == pubsub.CreatedEvent &&
+			ev.Payload.SessionID == p.session.ID && ev.Payload.FinishReason() == message.FinishReasonCostLimit {
+			sessionID := ev.Payload.SessionID
+			cmds = append(cmds, func() tea.Msg {
+				return p.resumeCostLimitDialog(sessionID)
+			})
+		}
 		if p.focusedPane == PanelTypeSplash {
 			u, cmd := p.splash.Update(msg)
 			p.splash = u.(splash.Splash)
@@ -496,7 +516,7 @@ func (p *chatPage) View() string {
 	if p.showingDetails {
 		style := t.S().Base.
 			Width(p.detailsWidth).
-			Border(lipgloss.RoundedBorder()).
+			Border(styles.Border()).
 			BorderForeground(t.BorderFocus)
 		version := t.S().Base.Foreground(t.Border).Width(p.detailsWidth - 4).AlignHorizontal(lipgloss.Right).Render(version.Version)
 		details := style.Render(
@@ -762,6 +782,9 @@ func (p *chatPage) sendMessage(text string, attachments []message.Attachment) te
 			if isCancelErr || isPermissionErr {
 				return nil
 			}
+			if errors.Is(err, agent.ErrSessionCostLimit) {
+				return p.costLimitDialog(session.ID, text, attachments)
+			}
 			return util.InfoMsg{
 				Type: util.InfoTypeError,
 				Msg:  err.Error(),
@@ -772,6 +795,44 @@ func (p *chatPage) sendMessage(text string, attachments []message.Attachment) te
 	return tea.Batch(cmds...)
 }
 
+// costLimitDialog asks the user whether to raise the cost limit of a session
+// that reached it, by another session_cost_limit worth of spending.
+func (p *chatPage) costLimitDialog(sessionID, text string, attachments []message.Attachment) tea.Msg {
+	sess, err := p.app.Sessions.Get(context.Background(), sessionID)
+	if err != nil {
+		return util.InfoMsg{
+			Type: util.InfoTypeError,
+			Msg:  err.Error(),
+		}
+	}
+	limit := p.app.AgentCoordinator.SessionCostLimit(sessionID)
+	step := cmp.Or(p.app.Config().Options.SessionCostLimit, limit)
+	return dialogs.OpenDialogMsg{
+		Model: costlimit.NewCostLimitDialog(sessionID, sess.Cost, limit, sess.Cost+step, text, attachments),
+	}
+}
+
+// resumeCostLimitDialog asks the user whether to raise the cost limit of a
+// session whose request was paused by it, and to resume that request.
+func (p *chatPage) resumeCostLimitDialog(sessionID string) tea.Msg {
+	msgs, err := p.app.Messages.List(context.Background(), sessionID)
+	if err != nil {
+		return util.InfoMsg{
+			Type: util.InfoTypeError,
+			Msg:  err.Error(),
+		}
+	}
+	var request string
+	for _, msg := range slices.Backward(msgs) {
+		if msg.Role == message.User {
+			request = msg.Content().Text
+			break
+		}
+	}
+	prompt := fmt.Sprintf("The previous request was paused because the session reached its cost limit, which has now been raised. Continue where you left off. The paused request was: `%s`", request)
+	return p.costLimitDialog(sessionID, prompt, nil)
+}
+
 func (p *chatPage) Bindings() []key.Binding {
 	bindings := []key.Binding{
 		p.keyMap.NewSession,
@@ -0,0 +1,31 @@
+package styles
+
+import (
+	"sync/atomic"
+
+	"charm.land/lipgloss/v2"
+)
+
+var accessible atomic.Bool
+
+// SetAccessible turns the accessible rendering mode on or off. In this mode
+// components avoid box-drawing borders, status glyphs and signals conveyed
+// only by color, so the output reads well with screen readers and on dumb
+// terminals.
+func SetAccessible(enabled bool) {
+	accessible.Store(enabled)
+}
+
+// Accessible reports whether the accessible rendering mode is on.
+func Accessible() bool {
+	return accessible.Load()
+}
+
+// Border returns the border used around dialogs and panels. In accessible
+// mode it is blank, keeping the layout but not drawing any lines.
+func Border() lipgloss.Border {
+	if Accessible() {
+		return lipgloss.HiddenBorder()
+	}
+	return lipgloss.RoundedBorder()
+}
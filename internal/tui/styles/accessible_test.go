@@ -0,0 +1,18 @@
+package styles
+
+import (
+	"testing"
+
+	"charm.land/lipgloss/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBorder(t *testing.T) {
+	defer SetAccessible(false)
+
+	require.Equal(t, lipgloss.RoundedBorder(), Border())
+
+	SetAccessible(true)
+	require.True(t, Accessible())
+	require.Equal(t, lipgloss.HiddenBorder(), Border())
+}
@@ -30,6 +30,7 @@ import (
 	"github.com/charmbracelet/crush/internal/tui/components/dialogs/permissions"
 	"github.com/charmbracelet/crush/internal/tui/components/dialogs/quit"
 	"github.com/charmbracelet/crush/internal/tui/components/dialogs/sessions"
+	"github.com/charmbracelet/crush/internal/tui/components/dialogs/tokens"
 	"github.com/charmbracelet/crush/internal/tui/page"
 	"github.com/charmbracelet/crush/internal/tui/page/chat"
 	"github.com/charmbracelet/crush/internal/tui/styles"
@@ -251,6 +252,16 @@ func (a *appModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			return nil
 		}
+	case commands.InspectPromptTokensMsg:
+		return a, func() tea.Msg {
+			breakdown, err := a.app.AgentCoordinator.InspectPrompt(context.Background(), msg.SessionID)
+			if err != nil {
+				return util.ReportError(err)()
+			}
+			return dialogs.OpenDialogMsg{
+				Model: tokens.NewTokensDialog(breakdown),
+			}
+		}
 	case commands.QuitMsg:
 		return a, util.CmdHandler(dialogs.OpenDialogMsg{
 			Model: quit.NewQuitDialog(),
@@ -590,7 +601,7 @@ func (a *appModel) View() tea.View {
 							t.S().Base.
 								Padding(1, 4).
 								Foreground(t.White).
-								BorderStyle(lipgloss.RoundedBorder()).
+								BorderStyle(styles.Border()).
 								BorderForeground(t.Primary).
 								Render("Window too small!"),
 						),
@@ -685,6 +696,8 @@ func handleMCPToolsEvent(ctx context.Context, name string) tea.Cmd {
 
 // New creates and initializes a new TUI application model.
 func New(app *app.App) *appModel {
+	styles.SetAccessible(app.Config().Options.TUI.Accessible)
+
 	chatPage := chat.New(app)
 	keyMap := DefaultKeyMap()
 	keyMap.pageBindings = chatPage.Bindings()
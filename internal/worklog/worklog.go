@@ -0,0 +1,145 @@
+// Package worklog collects recent session activity (edited files and git
+// commits) and writes human-readable worklog entries generated from it.
+package worklog
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/crush/internal/history"
+	"github.com/charmbracelet/crush/internal/session"
+)
+
+// SessionActivity describes what happened in a single session.
+type SessionActivity struct {
+	Title     string
+	UpdatedAt time.Time
+	Files     []string
+}
+
+// Activity is the raw material a worklog entry is generated from.
+type Activity struct {
+	Since    time.Time
+	Sessions []SessionActivity
+	Commits  []string
+}
+
+// Empty reports whether there is nothing to write about.
+func (a Activity) Empty() bool {
+	return len(a.Sessions) == 0 && len(a.Commits) == 0
+}
+
+// String renders the activity as plain text suitable for a model prompt.
+func (a Activity) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Activity since %s\n", a.Since.Format(time.RFC1123))
+
+	if len(a.Sessions) > 0 {
+		sb.WriteString("\nSessions:\n")
+		for _, s := range a.Sessions {
+			fmt.Fprintf(&sb, "- %s (last active %s)\n", s.Title, s.UpdatedAt.Format(time.DateTime))
+			for _, f := range s.Files {
+				fmt.Fprintf(&sb, "  - edited %s\n", f)
+			}
+		}
+	}
+
+	if len(a.Commits) > 0 {
+		sb.WriteString("\nCommits:\n")
+		for _, c := range a.Commits {
+			fmt.Fprintf(&sb, "- %s\n", c)
+		}
+	}
+	return sb.String()
+}
+
+// Collect gathers the sessions updated since the given time, the files each
+// of them edited, and the git commits made in workingDir over the same period.
+func Collect(ctx context.Context, sessions session.Service, files history.Service, workingDir string, since time.Time) (Activity, error) {
+	activity := Activity{Since: since}
+
+	all, err := sessions.List(ctx)
+	if err != nil {
+		return activity, fmt.Errorf("failed to list sessions: %w", err)
+	}
+	for _, s := range all {
+		updatedAt := time.Unix(s.UpdatedAt, 0)
+		if updatedAt.Before(since) {
+			continue
+		}
+		latest, err := files.ListLatestSessionFiles(ctx, s.ID)
+		if err != nil {
+			return activity, fmt.Errorf("failed to list files for session %s: %w", s.ID, err)
+		}
+		var paths []string
+		for _, f := range latest {
+			path := f.Path
+			if rel, err := filepath.Rel(workingDir, path); err == nil && !strings.HasPrefix(rel, "..") {
+				path = rel
+			}
+			paths = append(paths, filepath.ToSlash(path))
+		}
+		slices.Sort(paths)
+		activity.Sessions = append(activity.Sessions, SessionActivity{
+			Title:     s.Title,
+			UpdatedAt: updatedAt,
+			Files:     slices.Compact(paths),
+		})
+	}
+	slices.SortFunc(activity.Sessions, func(a, b SessionActivity) int {
+		return a.UpdatedAt.Compare(b.UpdatedAt)
+	})
+
+	activity.Commits = gitCommits(ctx, workingDir, since)
+	return activity, nil
+}
+
+// gitCommits returns the one-line summaries of non-merge commits made since
+// the given time, oldest first. It returns nil when workingDir is not a git
+// repository or git is not available.
+func gitCommits(ctx context.Context, workingDir string, since time.Time) []string {
+	cmd := exec.CommandContext(ctx, "git", "log", "--no-merges", "--reverse",
+		"--since="+since.Format(time.RFC3339), "--pretty=format:%h %s")
+	cmd.Dir = workingDir
+	out, err := cmd.Output()
+	if err != nil {
+		return nil
+	}
+	var commits []string
+	for line := range strings.SplitSeq(strings.TrimSpace(string(out)), "\n") {
+		if line != "" {
+			commits = append(commits, line)
+		}
+	}
+	return commits
+}
+
+// Write prepends an entry for the given date to the worklog file at path,
+// creating it if needed, so the newest entry is always at the top.
+func Write(path string, date time.Time, entry string) error {
+	existing, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read worklog: %w", err)
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "## %s\n\n%s\n", date.Format(time.DateOnly), strings.TrimSpace(entry))
+	if len(existing) > 0 {
+		sb.WriteString("\n")
+		sb.Write(existing)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create worklog directory: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(sb.String()), 0o644); err != nil {
+		return fmt.Errorf("failed to write worklog: %w", err)
+	}
+	return nil
+}
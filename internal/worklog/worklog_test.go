@@ -0,0 +1,46 @@
+package worklog
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWritePrependsEntries(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "docs", "WORKLOG.md")
+
+	require.NoError(t, Write(path, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), "- first\n"))
+	require.NoError(t, Write(path, time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC), "- second"))
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, "## 2025-01-02\n\n- second\n\n## 2025-01-01\n\n- first\n", string(content))
+}
+
+func TestActivity(t *testing.T) {
+	t.Parallel()
+
+	since := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	require.True(t, Activity{Since: since}.Empty())
+
+	activity := Activity{
+		Since: since,
+		Sessions: []SessionActivity{{
+			Title:     "Fix login",
+			UpdatedAt: since.Add(time.Hour),
+			Files:     []string{"auth/login.go"},
+		}},
+		Commits: []string{"abc1234 fix: handle expired tokens"},
+	}
+	require.False(t, activity.Empty())
+
+	out := activity.String()
+	require.Contains(t, out, "- Fix login (last active 2025-01-01 01:00:00)")
+	require.Contains(t, out, "  - edited auth/login.go")
+	require.Contains(t, out, "- abc1234 fix: handle expired tokens")
+}